@@ -1,7 +1,6 @@
 package main
 
 import (
-	"bufio"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -16,62 +15,184 @@ func printUsage() {
 
 Usage:
   tgo                      - Interactive task management
-  tgo start <number>       - Start/stop task timer
-  tgo done <number>        - Mark task complete
+  tgo add <task> [--priority A-Z|P0-P3] - Add a task (due:fri, due:2026-11-01 or due:+3d sets a due date, #word tags it)
+  tgo prio <number|id> <level> - Set a task's priority (A-Z, P0-P3, or none)
+  tgo due <number|id> <date>   - Set or clear (none) a task's due date
+  tgo tag <number|id> <tag>... - Add tags to a task (-tag removes one)
+  tgo block <number|id> <blocker> [--by-list <name>] - Mark a task as waiting for another (any list)
+  tgo unblock <number|id> [blocker] - Remove one or all of a task's blockers
+  tgo agenda               - Show open tasks with due dates from all lists
+  tgo start <number|id>    - Start/stop task timer (pauses a timer running in any list)
+  tgo status               - Show the running task across all lists
+  tgo add <task> --parent <number|id> - Add a subtask under another task
+  tgo done <number|id> [--with-subtasks] - Mark task complete (asks about open subtasks)
+  tgo undone <number|id>   - Reopen a completed task (alias: reopen)
+  tgo log <number|id> <duration> [--at <time>] - Log time worked (e.g. 1h30m --at "yesterday 14:00")
+  tgo session list|edit|rm <number|id> [n]  - Show or change recorded sessions
+  tgo note <number|id> [text] - Set a task note (opens $EDITOR without text)
+  tgo report [--from <time>] [--to <time>] [--group-by day|week|list|task] - Summarize tracked time
+  tgo export --format csv|ics [--from] [--to] [--round 15m] [--output <file>] - Export sessions
+  tgo export md|todotxt [list] - Print a list as a Markdown checklist or todo.txt
+  tgo import md|todotxt <file> [--list <name>] - Create a list from a checklist or todo.txt file
+  tgo fsck [--repair]      - Check lists for inconsistent sessions and timers
   tgo set-folder <path>    - Configure task directory
+  tgo set-default <list>   - Set the list used when --list is omitted
+  tgo set-idle <duration|off> [--policy trim|keep] - Limit session length (asks when run in a terminal)
+  tgo ls | list [--tag <tag>] - Show all task lists, or the tasks with a tag across all lists
+  tgo show [list]          - Print a list without prompting (--status active,pending,done, --tag <tag>)
   tgo create-list <name>   - Create new task list
   tgo remove-list          - Remove task list
   tgo help                 - Show this help
 
+Options:
+  --list <name>            - Target a list by file name or title (add, start, done, note, due, show, fsck, report, export)
+  --hide-notes             - Hide task notes (interactive mode, show)
+  --json                   - Print results and errors as JSON (non-zero exit on failure)
+
+Exit Codes:
+  0 success, 1 other error, 2 invalid argument, 3 not found, 4 I/O error, 5 conflict
+
 Interactive Commands:
-  <number>        - Start/stop task timer
-  add <task>      - Add new task
-  remove <number> - Remove task
-  done <number>   - Mark task complete
-  r | return      - Return to main menu
-  q | quit        - Exit program
+  <number|id>        - Start/stop task timer
+  add <task>         - Add new task (--priority A-Z|P0-P3, due:<date>, #tag)
+  sub <number|id> <task> - Add a subtask
+  prio <number|id> <level> - Set or clear (none) task priority
+  due <number|id> <date>   - Set or clear (none) task due date
+  tag <number|id> <tag>... - Add tags (-tag removes one)
+  block <number|id> <number|id> - Mark a task as blocked by another
+  unblock <number|id> [number|id] - Remove a task's blockers
+  filter [#tag]      - Show only tasks with a tag (no tag clears the filter)
+  remove <number|id> - Remove task
+  done <number|id>   - Mark task complete
+  u <number|id>      - Reopen completed task (also: undone, reopen)
+  log <number|id> <duration> [start time] - Log time worked
+  note <number|id> [text] - Set task note (opens $EDITOR without text)
+  notes              - Show/hide task notes
+  r | return         - Return to main menu
+  q | quit           - Exit program
+
+Full-screen Keys (when running in a terminal):
+  ↑/↓ or j/k      - Move selection
+  space | enter   - Start/stop task timer
+  d / u / x       - Mark done / reopen / delete task
+  a / A           - Add task / add subtask under the selected task
+  n / p / N       - Edit note / set priority / show-hide notes
+  t / /           - Edit tags / filter by tag
+  tab | ←/→       - Switch between tasks and lists (c creates, x removes a list)
+  q | ctrl-c      - Exit program
+
+Tasks can be addressed by their position or by the short ID shown
+next to them (any unique prefix of at least 4 characters).
 
 Examples:
   tgo set-folder ~/Tasks
   tgo create-list "Sprint Planning"
   tgo
   tgo start 3
+  tgo done 2 --list sprint-planning
+  tgo --json list
 `)
 }
 
 func runCLI() {
-	config, err := loadConfig()
-	if err != nil {
-		fmt.Printf("Configuration error: %v\n", err)
-		os.Exit(1)
+	jsonFlag, args := extractBoolFlag(os.Args[1:], "--json")
+	if jsonFlag {
+		enableJSONOutput()
 	}
+	os.Args = append(os.Args[:1], args...)
 
-	if len(os.Args) < 2 {
-		runInteractiveMode(config)
+	config, err := loadConfig()
+	if err != nil {
+		err = newError(errIO, "configuration error: %v", err)
+		reportError(err)
+		os.Exit(exitCode(err))
+	}
+	configureIdle(config)
+	noteActivity()
+
+	hideNotes, args := extractBoolFlag(args, "--hide-notes")
+	if len(args) == 0 {
+		if jsonOutput {
+			err := newError(errInvalidArgument, "interactive mode does not support --json")
+			reportError(err)
+			os.Exit(exitCode(err))
+		}
+		runInteractiveMode(config, DisplayOptions{HideNotes: hideNotes, ShowCommands: true})
 		return
 	}
 
 	command := os.Args[1]
 	switch command {
 	case "set-folder":
-		handleSetFolder(config)
+		err = handleSetFolder(config)
+	case "set-default":
+		err = handleSetDefault(config)
 	case "create-list":
-		handleCreateList(config)
+		err = handleCreateList(config)
 	case "remove-list":
-		handleRemoveList(config)
+		err = handleRemoveList(config)
+	case "list", "ls":
+		err = handleList(config)
+	case "show":
+		err = handleShow(config)
+	case "add":
+		err = handleAdd(config)
+	case "prio", "priority":
+		err = handlePriority(config)
+	case "due":
+		err = handleDue(config)
+	case "tag":
+		err = handleTag(config)
+	case "block":
+		err = handleBlock(config)
+	case "unblock":
+		err = handleUnblock(config)
+	case "agenda":
+		err = handleAgenda(config)
 	case "start":
-		handleStartTask(config)
+		err = handleStartTask(config)
 	case "done":
-		handleMarkDone(config)
+		err = handleMarkDone(config)
+	case "undone", "reopen":
+		err = handleTaskCommand(config, "reopen", reopenTask)
+	case "note":
+		err = handleNote(config)
+	case "log":
+		err = handleLog(config)
+	case "session", "sessions":
+		err = handleSession(config)
+	case "fsck":
+		err = handleFsck(config)
+	case "status":
+		err = handleStatus(config)
+	case "set-idle":
+		err = handleSetIdle(config)
+	case "report":
+		err = handleReport(config)
+	case "export":
+		err = handleExport(config)
+	case "import":
+		err = handleImport(config)
 	case "help", "-h", "--help":
 		printUsage()
 	default:
-		fmt.Printf("❌ Unknown command: %s\n", command)
-		printUsage()
+		err = newError(errInvalidArgument, "unknown command: %s", command)
+		reportError(err)
+		if !jsonOutput {
+			printUsage()
+		}
+		os.Exit(exitCode(err))
+	}
+
+	if err != nil {
+		reportError(err)
+		os.Exit(exitCode(err))
 	}
 }
 
-func runInteractiveMode(config *Config) {
+var errNoTaskDir = newError(errInvalidArgument, "no task directory configured, use: tgo set-folder <path>")
+
+func runInteractiveMode(config *Config, opts DisplayOptions) {
 	if config.TaskDir == "" {
 		fmt.Println("🔧 No task directory configured")
 		fmt.Println("Use: tgo set-folder <path>")
@@ -82,10 +203,16 @@ func runInteractiveMode(config *Config) {
 	if err != nil {
 		fmt.Printf("📋 No task lists found in: %s\n\n", config.TaskDir)
 		fmt.Println("Let's create your first task list!")
-		handleCreateFirstList(config)
+		handleCreateFirstList(config, opts)
 		return
 	}
 
+	if canRunTUI() {
+		if err := runTUI(config, taskFiles, opts); err == nil {
+			return
+		}
+	}
+
 	taskFile, err := selectTaskFile(config.TaskDir, taskFiles)
 	if err != nil {
 		fmt.Printf("❌ %v\n", err)
@@ -98,31 +225,53 @@ func runInteractiveMode(config *Config) {
 		return
 	}
 
-	clearScreen()
-	displayTaskList(taskList, filepath.Base(taskFile))
+	view := &liveView{taskList: taskList, fileName: filepath.Base(taskFile), opts: &opts}
+	view.draw()
+
+	changes, stopWatch := watchFile(taskFile)
+	defer stopWatch()
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	liveTimer := isTerminal(os.Stdout)
 
-	scanner := bufio.NewScanner(os.Stdin)
 	for {
-		fmt.Print("\n> ")
-		if !scanner.Scan() {
-			break
-		}
+		select {
+		case line, ok := <-stdin.wait():
+			stdin.consumed()
+			if !ok {
+				return
+			}
+			noteActivity()
 
-		input := strings.TrimSpace(scanner.Text())
-		if input == "" {
-			continue
-		}
+			input := strings.TrimSpace(line)
+			if input == "" {
+				fmt.Print("\n> ")
+				continue
+			}
 
-		if handleInteractiveCommand(input, taskList, taskFile) {
-			break
-		}
+			if handleInteractiveCommand(input, taskList, taskFile, &opts) {
+				return
+			}
+			view.draw()
 
-		clearScreen()
-		displayTaskList(taskList, filepath.Base(taskFile))
+		case <-changes:
+			if !changedOnDisk(taskFile, taskList) {
+				continue
+			}
+			if fresh, err := loadTasks(taskFile); err == nil {
+				*taskList = *fresh
+				view.refresh()
+			}
+
+		case <-ticker.C:
+			if liveTimer && hasActiveTask(taskList) {
+				view.refresh()
+			}
+		}
 	}
 }
 
-func handleInteractiveCommand(input string, taskList *TaskList, taskFile string) bool {
+func handleInteractiveCommand(input string, taskList *TaskList, taskFile string, opts *DisplayOptions) bool {
 	switch {
 	case input == "q" || input == "quit" || input == "exit":
 		return true
@@ -130,91 +279,171 @@ func handleInteractiveCommand(input string, taskList *TaskList, taskFile string)
 		runCLI()
 		return true
 	case strings.HasPrefix(input, "add "):
-		handleAddTask(input, taskList, taskFile)
+		handleAddTask(commandArg(input), taskList, taskFile)
 	case strings.HasPrefix(input, "a "):
-		handleAddTask(input, taskList, taskFile)
+		handleAddTask(commandArg(input), taskList, taskFile)
+	case strings.HasPrefix(input, "sub "):
+		handleSubtaskTask(commandArg(input), taskList, taskFile)
 	case strings.HasPrefix(input, "remove "):
-		handleRemoveTask(input, taskList, taskFile)
+		handleRemoveTask(commandArg(input), taskList, taskFile)
 	case strings.HasPrefix(input, "r "):
-		handleRemoveTask(input, taskList, taskFile)
+		handleRemoveTask(commandArg(input), taskList, taskFile)
 	case strings.HasPrefix(input, "done "):
-		handleDoneTask(input, taskList, taskFile)
+		handleDoneTask(commandArg(input), taskList, taskFile)
 	case strings.HasPrefix(input, "d "):
-		handleDoneTask(input, taskList, taskFile)
+		handleDoneTask(commandArg(input), taskList, taskFile)
+	case strings.HasPrefix(input, "undone "), strings.HasPrefix(input, "reopen "), strings.HasPrefix(input, "u "):
+		handleReopenTask(commandArg(input), taskList, taskFile)
+	case strings.HasPrefix(input, "prio "), strings.HasPrefix(input, "p "):
+		handlePriorityTask(commandArg(input), taskList, taskFile)
+	case strings.HasPrefix(input, "due "):
+		handleDueTask(commandArg(input), taskList, taskFile)
+	case strings.HasPrefix(input, "block "):
+		handleBlockTask(commandArg(input), taskList, taskFile)
+	case strings.HasPrefix(input, "unblock "):
+		handleUnblockTask(commandArg(input), taskList, taskFile)
+	case strings.HasPrefix(input, "tag "):
+		handleTagTask(commandArg(input), taskList, taskFile)
+	case input == "filter" || strings.HasPrefix(input, "filter "):
+		handleFilter(commandArg(input), opts)
+	case strings.HasPrefix(input, "log "):
+		handleLogTask(commandArg(input), taskList, taskFile)
+	case input == "notes":
+		opts.HideNotes = !opts.HideNotes
+	case strings.HasPrefix(input, "note "):
+		handleNoteTask(commandArg(input), taskList, taskFile)
+	case strings.HasPrefix(input, "n "):
+		handleNoteTask(commandArg(input), taskList, taskFile)
 	default:
-		if taskNum, err := strconv.Atoi(input); err == nil {
-			handleToggleTimer(taskNum, taskList, taskFile)
+		if !strings.Contains(input, " ") {
+			handleToggleTimer(input, taskList, taskFile)
 		} else {
-			fmt.Println("❌ Invalid command. Type a number, 'add / a <task>', 'remove / r <number>', 'done / d <number>', 'r' to return, or 'q' to quit")
+			fmt.Println("❌ Invalid command. Type a number or ID, 'add / a <task>', 'sub <number> <task>', 'remove / r <number>', 'done / d <number>', 'undone / u <number>', 'note / n <number> [text]', 'prio / p <number> <level>', 'due <number> <date>', 'tag <number> <tag>', 'block <number> <number>', 'filter [#tag]', 'r' to return, or 'q' to quit")
 		}
 	}
 	return false
 }
 
-func handleAddTask(input string, taskList *TaskList, taskFile string) {
-	taskTitle := strings.TrimSpace(input[4:])
-	if taskTitle == "" {
-		fmt.Println("❌ Task title cannot be empty")
+func commandArg(input string) string {
+	_, arg, _ := strings.Cut(input, " ")
+	return strings.TrimSpace(arg)
+}
+
+func handleAddTask(text string, taskList *TaskList, taskFile string) {
+	input, err := parseTaskInput(text)
+	if err != nil {
+		fmt.Printf("❌ %v\n", err)
 		return
 	}
 
-	addTask(taskList, taskTitle)
-	if err := saveTasks(taskFile, taskList); err != nil {
+	err = updateTasks(taskFile, taskList, func(taskList *TaskList) error {
+		addTask(taskList, input)
+		return nil
+	})
+	if err != nil {
 		fmt.Printf("❌ Save error: %v\n", err)
 	}
 }
 
-func handleRemoveTask(input string, taskList *TaskList, taskFile string) {
-	taskNumStr := strings.TrimSpace(input[7:])
-	taskNum, err := strconv.Atoi(taskNumStr)
+func handleAdd(config *Config) error {
+	listName, args := extractFlag(os.Args[2:], "--list")
+	parentRef, args := extractFlag(args, "--parent")
+	input, err := parseTaskInput(strings.Join(args, " "))
 	if err != nil {
-		fmt.Printf("❌ '%s' is not a valid number\n", taskNumStr)
-		return
+		return err
 	}
 
-	if err := removeTask(taskList, taskNum); err != nil {
-		fmt.Printf("❌ %v\n", err)
-		return
+	taskFile, taskList, err := openTaskList(config, listName)
+	if err != nil {
+		return err
 	}
 
-	if err := saveTasks(taskFile, taskList); err != nil {
-		fmt.Printf("❌ Save error: %v\n", err)
+	var parentID int64
+	if parentRef != "" {
+		parentNum, err := resolveTask(taskList, parentRef)
+		if err != nil {
+			return err
+		}
+		parentID = taskList.Items[parentNum-1].ID
 	}
+
+	var id int64
+	err = updateTasks(taskFile, taskList, func(taskList *TaskList) error {
+		if parentID == 0 {
+			id = addTask(taskList, input).ID
+			return nil
+		}
+		task, err := addSubtask(taskList, parentID, input)
+		if err != nil {
+			return err
+		}
+		id = task.ID
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	return printTaskResult("add", taskFile, taskList, id)
 }
 
-func handleDoneTask(input string, taskList *TaskList, taskFile string) {
-	taskNumStr := strings.TrimSpace(input[5:])
-	taskNum, err := strconv.Atoi(taskNumStr)
+func handleRemoveTask(taskRef string, taskList *TaskList, taskFile string) {
+	if err := updateTask(taskFile, taskList, taskRef, removeTask); err != nil {
+		fmt.Printf("❌ %v\n", err)
+	}
+}
+
+func handleDoneTask(taskRef string, taskList *TaskList, taskFile string) {
+	taskNum, err := resolveTask(taskList, taskRef)
 	if err != nil {
-		fmt.Printf("❌ '%s' is not a valid number\n", taskNumStr)
+		fmt.Printf("❌ %v\n", err)
 		return
 	}
 
-	if err := markTaskComplete(taskList, taskNum); err != nil {
+	withSubtasks := confirmCloseSubtasks(taskList, taskNum)
+	if err := updateTaskByID(taskFile, taskList, taskList.Items[taskNum-1].ID, completeTask(withSubtasks)); err != nil {
 		fmt.Printf("❌ %v\n", err)
-		return
 	}
+}
 
-	if err := saveTasks(taskFile, taskList); err != nil {
-		fmt.Printf("❌ Save error: %v\n", err)
+func handleReopenTask(taskRef string, taskList *TaskList, taskFile string) {
+	if err := updateTask(taskFile, taskList, taskRef, reopenTask); err != nil {
+		fmt.Printf("❌ %v\n", err)
 	}
 }
 
-func handleToggleTimer(taskNum int, taskList *TaskList, taskFile string) {
-	if err := toggleTaskTimer(taskList, taskNum); err != nil {
+func handleNoteTask(arg string, taskList *TaskList, taskFile string) {
+	taskRef, note, hasText := strings.Cut(arg, " ")
+	taskNum, err := resolveTask(taskList, taskRef)
+	if err != nil {
 		fmt.Printf("❌ %v\n", err)
 		return
 	}
 
-	if err := saveTasks(taskFile, taskList); err != nil {
-		fmt.Printf("❌ Save error: %v\n", err)
+	if !hasText {
+		note, err = editText(taskList.Items[taskNum-1].Comment)
+		if err != nil {
+			fmt.Printf("❌ %v\n", err)
+			return
+		}
+	}
+
+	err = updateTask(taskFile, taskList, taskRef, func(taskList *TaskList, taskNum int) error {
+		return setTaskNote(taskList, taskNum, note)
+	})
+	if err != nil {
+		fmt.Printf("❌ %v\n", err)
+	}
+}
+
+func handleToggleTimer(taskRef string, taskList *TaskList, taskFile string) {
+	if err := updateTask(taskFile, taskList, taskRef, toggleTaskTimer); err != nil {
+		fmt.Printf("❌ %v\n", err)
 	}
 }
 
-func handleSetFolder(config *Config) {
+func handleSetFolder(config *Config) error {
 	if len(os.Args) < 3 {
-		fmt.Println("❌ Folder path required")
-		return
+		return newError(errInvalidArgument, "folder path required")
 	}
 
 	folder := os.Args[2]
@@ -225,207 +454,431 @@ func handleSetFolder(config *Config) {
 
 	absDir, err := filepath.Abs(folder)
 	if err != nil {
-		fmt.Printf("❌ Invalid path: %v\n", err)
-		return
+		return newError(errInvalidArgument, "invalid path: %v", err)
 	}
 
 	if _, err := os.Stat(absDir); os.IsNotExist(err) {
-		fmt.Printf("❌ Directory not found: %s\n", absDir)
-		return
+		return newError(errNotFound, "directory not found: %s", absDir)
 	}
 
 	config.TaskDir = absDir
 	if err := saveConfig(config); err != nil {
-		fmt.Printf("❌ Save error: %v\n", err)
-		return
+		return newError(errIO, "save error: %v", err)
+	}
+
+	if jsonOutput {
+		printJSON(commandResult{OK: true, Command: "set-folder", Path: absDir})
+		return nil
 	}
 
 	fmt.Printf("✅ Task directory set: %s\n", absDir)
 	showDirContents(absDir)
+	return nil
 }
 
-func handleCreateList(config *Config) {
+func handleSetDefault(config *Config) error {
 	if config.TaskDir == "" {
-		fmt.Println("❌ No task directory configured")
-		fmt.Println("Use: tgo set-folder <path>")
-		return
+		return errNoTaskDir
+	}
+
+	if len(os.Args) < 3 {
+		if config.DefaultList == "" {
+			return newError(errInvalidArgument, "list name required")
+		}
+		if jsonOutput {
+			printJSON(commandResult{OK: true, Command: "set-default", List: config.DefaultList})
+			return nil
+		}
+		fmt.Printf("📌 Default list: %s\n", config.DefaultList)
+		return nil
+	}
+
+	listName := strings.Join(os.Args[2:], " ")
+	taskFile, err := findListFile(config.TaskDir, listName)
+	if err != nil {
+		if !jsonOutput {
+			defer showDirContents(config.TaskDir)
+		}
+		return err
+	}
+
+	config.DefaultList = strings.TrimSuffix(filepath.Base(taskFile), ".json")
+	if err := saveConfig(config); err != nil {
+		return newError(errIO, "save error: %v", err)
+	}
+
+	if jsonOutput {
+		printJSON(commandResult{OK: true, Command: "set-default", List: config.DefaultList, Path: taskFile})
+		return nil
+	}
+
+	fmt.Printf("✅ Default list set: %s\n", config.DefaultList)
+	return nil
+}
+
+func handleCreateList(config *Config) error {
+	if config.TaskDir == "" {
+		return errNoTaskDir
 	}
 
 	var listName string
 	if len(os.Args) < 3 {
+		if jsonOutput {
+			return newError(errInvalidArgument, "list name required")
+		}
 		fmt.Print("Enter list name: ")
-		scanner := bufio.NewScanner(os.Stdin)
-		if scanner.Scan() {
-			listName = strings.TrimSpace(scanner.Text())
+		if line, ok := readLine(); ok {
+			listName = strings.TrimSpace(line)
 		}
 		if listName == "" {
-			fmt.Println("❌ List name cannot be empty")
-			return
+			return newError(errInvalidArgument, "list name cannot be empty")
 		}
 	} else {
 		listName = strings.Join(os.Args[2:], " ")
 	}
 
 	if err := createNewList(config.TaskDir, listName); err != nil {
-		fmt.Printf("❌ %v\n", err)
-		return
+		return err
+	}
+
+	if jsonOutput {
+		taskFile := filepath.Join(config.TaskDir, sanitizeListName(listName)+".json")
+		printJSON(commandResult{OK: true, Command: "create-list", List: listName, Path: taskFile})
+		return nil
 	}
 
 	fmt.Printf("✅ Created list: %s\n", listName)
 	showDirContents(config.TaskDir)
+	return nil
 }
 
-func handleRemoveList(config *Config) {
+func handleRemoveList(config *Config) error {
 	if config.TaskDir == "" {
-		fmt.Println("❌ No task directory configured")
-		fmt.Println("Use: tgo set-folder <path>")
-		return
+		return errNoTaskDir
+	}
+	if jsonOutput {
+		return newError(errInvalidArgument, "remove-list asks for confirmation and does not support --json")
 	}
 
 	taskFiles, err := findTaskFiles(config.TaskDir)
 	if err != nil {
-		fmt.Printf("❌ %v\n", err)
-		showDirContents(config.TaskDir)
-		return
+		defer showDirContents(config.TaskDir)
+		return err
 	}
 
-	if len(taskFiles) == 1 {
-		fmt.Printf("Remove '%s'? (y/N): ", taskFiles[0])
-		scanner := bufio.NewScanner(os.Stdin)
-		if scanner.Scan() && strings.ToLower(scanner.Text()) == "y" {
-			if err := os.Remove(filepath.Join(config.TaskDir, taskFiles[0])); err != nil {
-				fmt.Printf("❌ Failed to remove: %v\n", err)
-				return
-			}
-			fmt.Printf("✅ Removed: %s\n", taskFiles[0])
+	selectedFile := taskFiles[0]
+	if len(taskFiles) > 1 {
+		fmt.Printf("📋 Found %d task lists:\n\n", len(taskFiles))
+		for i, file := range taskFiles {
+			displayName := strings.TrimSuffix(file, ".json")
+			fmt.Printf("%d. %s\n", i+1, displayName)
 		}
-		return
-	}
-
-	fmt.Printf("📋 Found %d task lists:\n\n", len(taskFiles))
-	for i, file := range taskFiles {
-		displayName := strings.TrimSuffix(file, ".json")
-		fmt.Printf("%d. %s\n", i+1, displayName)
-	}
 
-	fmt.Printf("\nSelect list to remove (1-%d): ", len(taskFiles))
-	var choice int
-	if _, err := fmt.Scanf("%d", &choice); err != nil || choice < 1 || choice > len(taskFiles) {
-		fmt.Println("❌ Invalid selection")
-		return
+		fmt.Printf("\nSelect list to remove (1-%d): ", len(taskFiles))
+		line, _ := readLine()
+		choice, err := strconv.Atoi(strings.TrimSpace(line))
+		if err != nil || choice < 1 || choice > len(taskFiles) {
+			return newError(errInvalidArgument, "invalid selection")
+		}
+		selectedFile = taskFiles[choice-1]
 	}
 
-	selectedFile := taskFiles[choice-1]
 	fmt.Printf("Remove '%s'? (y/N): ", selectedFile)
-	scanner := bufio.NewScanner(os.Stdin)
-	if scanner.Scan() && strings.ToLower(scanner.Text()) == "y" {
-		if err := os.Remove(filepath.Join(config.TaskDir, selectedFile)); err != nil {
-			fmt.Printf("❌ Failed to remove: %v\n", err)
-			return
+	if line, ok := readLine(); ok && strings.ToLower(line) == "y" {
+		if err := removeListFile(filepath.Join(config.TaskDir, selectedFile)); err != nil {
+			return newError(errIO, "failed to remove: %v", err)
 		}
 		fmt.Printf("✅ Removed: %s\n", selectedFile)
 	}
+	return nil
 }
 
-func handleStartTask(config *Config) {
-	if len(os.Args) < 3 {
-		fmt.Println("❌ Task number required")
-		return
+func handleStartTask(config *Config) error {
+	return handleTaskCommand(config, "start", toggleTaskTimer)
+}
+
+func handleMarkDone(config *Config) error {
+	listName, args := extractFlag(os.Args[2:], "--list")
+	withSubtasks, args := extractBoolFlag(args, "--with-subtasks")
+	if len(args) < 1 {
+		return newError(errInvalidArgument, "task number or ID required")
 	}
 
-	taskNum, err := strconv.Atoi(os.Args[2])
+	taskFile, taskList, err := openTaskList(config, listName)
 	if err != nil {
-		fmt.Printf("❌ '%s' is not a valid number\n", os.Args[2])
-		return
+		return err
+	}
+	taskNum, err := resolveTask(taskList, args[0])
+	if err != nil {
+		return err
 	}
 
-	if config.TaskDir == "" {
-		fmt.Println("❌ No task directory configured")
-		return
+	if !withSubtasks {
+		withSubtasks = confirmCloseSubtasks(taskList, taskNum)
+	}
+	id := taskList.Items[taskNum-1].ID
+	if err := updateTaskByID(taskFile, taskList, id, completeTask(withSubtasks)); err != nil {
+		return err
+	}
+	return printTaskResult("done", taskFile, taskList, id)
+}
+
+func handleTaskCommand(config *Config, command string, apply func(*TaskList, int) error) error {
+	listName, args := extractFlag(os.Args[2:], "--list")
+	if len(args) < 1 {
+		return newError(errInvalidArgument, "task number or ID required")
 	}
+	return runTaskCommand(config, command, listName, args[0], apply)
+}
 
-	taskFiles, err := findTaskFiles(config.TaskDir)
+func runTaskCommand(config *Config, command string, listName string, taskRef string, apply func(*TaskList, int) error) error {
+	taskFile, taskList, err := openTaskList(config, listName)
 	if err != nil {
-		fmt.Printf("❌ %v\n", err)
-		return
+		return err
 	}
 
-	taskFile, err := selectTaskFile(config.TaskDir, taskFiles)
+	taskNum, err := resolveTask(taskList, taskRef)
 	if err != nil {
-		fmt.Printf("❌ %v\n", err)
-		return
+		return err
+	}
+
+	id := taskList.Items[taskNum-1].ID
+	if err := updateTaskByID(taskFile, taskList, id, apply); err != nil {
+		return err
+	}
+	return printTaskResult(command, taskFile, taskList, id)
+}
+
+func openTaskList(config *Config, listName string) (string, *TaskList, error) {
+	if config.TaskDir == "" {
+		return "", nil, errNoTaskDir
+	}
+
+	taskFile, err := resolveTaskFile(config, listName)
+	if err != nil {
+		return "", nil, err
 	}
 
 	taskList, err := loadTasks(taskFile)
 	if err != nil {
-		fmt.Printf("❌ Load error: %v\n", err)
-		return
+		return "", nil, newError(errIO, "load error: %v", err)
 	}
+	return taskFile, taskList, nil
+}
 
-	if err := toggleTaskTimer(taskList, taskNum); err != nil {
-		fmt.Printf("❌ %v\n", err)
-		return
+func resolveTaskFile(config *Config, listName string) (string, error) {
+	return findTaskFile(config, listName, !jsonOutput)
+}
+
+// findTaskFile resolves listName, the default list or the only list.
+// With several lists and none of those, it asks which one to use when
+// prompt is set and fails otherwise.
+func findTaskFile(config *Config, listName string, prompt bool) (string, error) {
+	if listName == "" {
+		listName = config.DefaultList
+	}
+	if listName != "" {
+		return findListFile(config.TaskDir, listName)
 	}
 
-	if err := saveTasks(taskFile, taskList); err != nil {
-		fmt.Printf("❌ Save error: %v\n", err)
+	taskFiles, err := findTaskFiles(config.TaskDir)
+	if err != nil {
+		return "", err
 	}
+	if len(taskFiles) == 1 {
+		return filepath.Join(config.TaskDir, taskFiles[0]), nil
+	}
+	if !prompt {
+		return "", newError(errInvalidArgument, "%d task lists found, use --list <name>", len(taskFiles))
+	}
+	return selectTaskFile(config.TaskDir, taskFiles)
 }
 
-func handleMarkDone(config *Config) {
-	if len(os.Args) < 3 {
-		fmt.Println("❌ Task number required")
-		return
+func extractFlag(args []string, name string) (string, []string) {
+	var value string
+	var rest []string
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		switch {
+		case arg == name && i+1 < len(args):
+			value = args[i+1]
+			i++
+		case strings.HasPrefix(arg, name+"="):
+			value = strings.TrimPrefix(arg, name+"=")
+		default:
+			rest = append(rest, arg)
+		}
+	}
+	return value, rest
+}
+
+func handleNote(config *Config) error {
+	listName, args := extractFlag(os.Args[2:], "--list")
+	if len(args) < 1 {
+		return newError(errInvalidArgument, "task number or ID required")
 	}
 
-	taskNum, err := strconv.Atoi(os.Args[2])
+	taskFile, taskList, err := openTaskList(config, listName)
 	if err != nil {
-		fmt.Printf("❌ '%s' is not a valid number\n", os.Args[2])
-		return
+		return err
+	}
+
+	taskNum, err := resolveTask(taskList, args[0])
+	if err != nil {
+		return err
 	}
 
+	var note string
+	if len(args) > 1 {
+		note = strings.Join(args[1:], " ")
+	} else {
+		note, err = editText(taskList.Items[taskNum-1].Comment)
+		if err != nil {
+			return err
+		}
+	}
+
+	id := taskList.Items[taskNum-1].ID
+	err = updateTaskByID(taskFile, taskList, id, func(taskList *TaskList, taskNum int) error {
+		return setTaskNote(taskList, taskNum, note)
+	})
+	if err != nil {
+		return err
+	}
+	return printTaskResult("note", taskFile, taskList, id)
+}
+
+func handleList(config *Config) error {
 	if config.TaskDir == "" {
-		fmt.Println("❌ No task directory configured")
-		return
+		return errNoTaskDir
 	}
 
+	tagFilter, _ := extractFlag(os.Args[2:], "--tag")
 	taskFiles, err := findTaskFiles(config.TaskDir)
 	if err != nil {
-		fmt.Printf("❌ %v\n", err)
-		return
+		return err
 	}
 
-	taskFile, err := selectTaskFile(config.TaskDir, taskFiles)
+	if tagFilter != "" {
+		tag, ok := normalizeTag(tagFilter)
+		if !ok {
+			return newError(errInvalidArgument, "invalid tag '%s'", tagFilter)
+		}
+		return listTagged(config, tag, taskFiles)
+	}
+
+	var summaries []listSummary
+	for _, file := range taskFiles {
+		taskList, err := loadTasks(filepath.Join(config.TaskDir, file))
+		if err != nil {
+			return newError(errIO, "cannot load %s: %v", file, err)
+		}
+		summaries = append(summaries, summarizeList(file, taskList, taskList.Items))
+	}
+
+	if jsonOutput {
+		printJSON(listsResult{OK: true, Command: "list", Lists: summaries})
+		return nil
+	}
+
+	fmt.Printf("📋 Task lists (%d):\n\n", len(summaries))
+	for _, summary := range summaries {
+		fmt.Printf("  %-24s Active: %d │ Pending: %d │ Done: %d │ Tracked: %s\n",
+			strings.TrimSuffix(summary.File, ".json"), summary.Active, summary.Pending, summary.Done,
+			formatDuration(summary.TotalSeconds*int64(time.Second)))
+	}
+	return nil
+}
+
+func handleShow(config *Config) error {
+	listName, args := extractFlag(os.Args[2:], "--list")
+	statusFilter, args := extractFlag(args, "--status")
+	tagFilter, args := extractFlag(args, "--tag")
+	hideNotes, args := extractBoolFlag(args, "--hide-notes")
+	if listName == "" {
+		listName = strings.Join(args, " ")
+	}
+
+	statuses, err := parseStatusFilter(statusFilter)
 	if err != nil {
-		fmt.Printf("❌ %v\n", err)
-		return
+		return err
 	}
 
+	var tag string
+	if tagFilter != "" {
+		var ok bool
+		if tag, ok = normalizeTag(tagFilter); !ok {
+			return newError(errInvalidArgument, "invalid tag '%s'", tagFilter)
+		}
+	}
+
+	if config.TaskDir == "" {
+		return errNoTaskDir
+	}
+	taskFile, err := findTaskFile(config, listName, false)
+	if err != nil {
+		return err
+	}
 	taskList, err := loadTasks(taskFile)
 	if err != nil {
-		fmt.Printf("❌ Load error: %v\n", err)
-		return
+		return newError(errIO, "load error: %v", err)
 	}
 
-	if err := markTaskComplete(taskList, taskNum); err != nil {
-		fmt.Printf("❌ %v\n", err)
-		return
+	opts := DisplayOptions{HideNotes: hideNotes, Statuses: statuses, Tag: tag}
+	if jsonOutput {
+		var tasks []Task
+		for _, i := range opts.tasks(taskList, opts.filter(StatusActive, StatusPending, StatusPaused, StatusDone)...) {
+			tasks = append(tasks, taskList.Items[i])
+		}
+		printJSON(showResult{OK: true, Command: "show", List: summarizeList(filepath.Base(taskFile), taskList, tasks)})
+		return nil
 	}
 
-	if err := saveTasks(taskFile, taskList); err != nil {
-		fmt.Printf("❌ Save error: %v\n", err)
+	displayTaskList(taskList, filepath.Base(taskFile), opts)
+	return nil
+}
+
+func parseStatusFilter(filter string) ([]TaskStatus, error) {
+	var statuses []TaskStatus
+	for _, name := range strings.Split(filter, ",") {
+		switch strings.ToLower(strings.TrimSpace(name)) {
+		case "":
+		case "active":
+			statuses = append(statuses, StatusActive)
+		case "pending":
+			statuses = append(statuses, StatusPending, StatusPaused)
+		case "paused":
+			statuses = append(statuses, StatusPaused)
+		case "done":
+			statuses = append(statuses, StatusDone)
+		default:
+			return nil, newError(errInvalidArgument, "unknown status '%s', use active, pending, paused or done", name)
+		}
+	}
+	return statuses, nil
+}
+
+func extractBoolFlag(args []string, name string) (bool, []string) {
+	found := false
+	var rest []string
+	for _, arg := range args {
+		if arg == name {
+			found = true
+			continue
+		}
+		rest = append(rest, arg)
 	}
+	return found, rest
 }
 
 func clearScreen() {
 	fmt.Print("\033[2J\033[H")
 }
 
-func handleCreateFirstList(config *Config) {
+func handleCreateFirstList(config *Config, opts DisplayOptions) {
 	fmt.Print("Enter your first list name: ")
-	scanner := bufio.NewScanner(os.Stdin)
-	if scanner.Scan() {
-		listName := strings.TrimSpace(scanner.Text())
+	if line, ok := readLine(); ok {
+		listName := strings.TrimSpace(line)
 		if listName == "" {
 			fmt.Println("❌ List name cannot be empty")
 			return
@@ -439,6 +892,6 @@ func handleCreateFirstList(config *Config) {
 		fmt.Printf("✅ Created your first list: %s\n", listName)
 		fmt.Println("🚀 Starting interactive mode...")
 		time.Sleep(time.Second)
-		runInteractiveMode(config)
+		runInteractiveMode(config, opts)
 	}
 }
\ No newline at end of file
@@ -2,6 +2,7 @@ package main
 
 import (
 	"encoding/json"
+	"fmt"
 	"os"
 	"path/filepath"
 )
@@ -15,19 +16,23 @@ func getConfigPath() string {
 
 func loadConfig() (*Config, error) {
 	configPath := getConfigPath()
-	data, err := os.ReadFile(configPath)
-	if err != nil {
-		if os.IsNotExist(err) {
-			config := &Config{}
-			saveConfig(config)
-			return config, nil
-		}
+	var config Config
+	err := readJSONFile(configPath, &config)
+	if err == nil {
+		return &config, nil
+	}
+	if os.IsNotExist(err) {
+		config := &Config{}
+		saveConfig(config)
+		return config, nil
+	}
+
+	var backup Config
+	if readJSONFile(configPath+backupSuffix, &backup) != nil {
 		return nil, err
 	}
-	
-	var config Config
-	err = json.Unmarshal(data, &config)
-	return &config, err
+	fmt.Fprintf(os.Stderr, "⚠️ Could not load %s (%v), using backup\n", configFile, err)
+	return &backup, nil
 }
 
 func saveConfig(config *Config) error {
@@ -36,5 +41,5 @@ func saveConfig(config *Config) error {
 	if err != nil {
 		return err
 	}
-	return os.WriteFile(configPath, data, 0644)
+	return writeFileAtomic(configPath, data, 0644)
 }
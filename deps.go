@@ -0,0 +1,335 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// openTaskIDs returns the tasks that can still block others.
+func openTaskIDs(taskList *TaskList) map[int64]bool {
+	open := make(map[int64]bool)
+	for i := range taskList.Items {
+		if !taskList.Items[i].IsDone() {
+			open[taskList.Items[i].ID] = true
+		}
+	}
+	return open
+}
+
+// resolvedTasks returns the tasks that were open before a change and have
+// since been completed or removed.
+func resolvedTasks(before map[int64]bool, taskList *TaskList) []int64 {
+	after := openTaskIDs(taskList)
+	var resolved []int64
+	for id := range before {
+		if !after[id] {
+			resolved = append(resolved, id)
+		}
+	}
+	return resolved
+}
+
+// unblockTasks drops the resolved blockers from every task in the list.
+func unblockTasks(taskList *TaskList, resolved []int64) bool {
+	changed := false
+	for i := range taskList.Items {
+		task := &taskList.Items[i]
+		if len(task.BlockedBy) == 0 {
+			continue
+		}
+
+		remaining := slices.DeleteFunc(slices.Clone(task.BlockedBy), func(id int64) bool {
+			return slices.Contains(resolved, id)
+		})
+		if len(remaining) == len(task.BlockedBy) {
+			continue
+		}
+		task.BlockedBy = remaining
+		changed = true
+		if len(remaining) == 0 && !task.IsDone() {
+			fmt.Fprintf(notices, "🔓 Unblocked: %s\n", task.Title)
+		}
+	}
+	return changed
+}
+
+// unblockOtherLists drops the resolved blockers from tasks in the other
+// lists of the folder.
+func unblockOtherLists(filePath string, resolved []int64) error {
+	dir, listFile := filepath.Dir(filePath), filepath.Base(filePath)
+	taskFiles, err := findTaskFiles(dir)
+	if err != nil {
+		return nil
+	}
+
+	for _, file := range taskFiles {
+		if file == listFile {
+			continue
+		}
+		otherPath := filepath.Join(dir, file)
+		taskList, err := loadTasks(otherPath)
+		if err != nil || !blocksAny(taskList, resolved) {
+			continue
+		}
+
+		err = modifyTasks(otherPath, taskList, func(taskList *TaskList) error {
+			unblockTasks(taskList, resolved)
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func blocksAny(taskList *TaskList, ids []int64) bool {
+	for i := range taskList.Items {
+		for _, id := range taskList.Items[i].BlockedBy {
+			if slices.Contains(ids, id) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// taskBlockedInfo marks open tasks that wait for other tasks, naming
+// blockers in the same list by their number.
+func taskBlockedInfo(taskList *TaskList, task *Task) string {
+	if len(task.BlockedBy) == 0 || task.IsDone() {
+		return ""
+	}
+
+	var blockers []string
+	elsewhere := 0
+	for _, id := range task.BlockedBy {
+		if taskNum, err := findTaskByID(taskList, id); err == nil {
+			blockers = append(blockers, strconv.Itoa(taskNum))
+		} else {
+			elsewhere++
+		}
+	}
+	if elsewhere > 0 {
+		blockers = append(blockers, fmt.Sprintf("%d in other lists", elsewhere))
+	}
+	return " [⛔ Blocked by " + strings.Join(blockers, ", ") + "]"
+}
+
+// dependencyCycle returns the chain of titles that would close a loop if
+// the task with id were blocked by blocker, or nil if there is none.
+// Dependencies are followed across every list in dir.
+func dependencyCycle(dir string, id int64, blocker *Task) []string {
+	blockedBy := map[int64][]int64{blocker.ID: blocker.BlockedBy}
+	titles := map[int64]string{blocker.ID: blocker.Title}
+	taskFiles, _ := findTaskFiles(dir)
+	for _, file := range taskFiles {
+		taskList, err := loadTasks(filepath.Join(dir, file))
+		if err != nil {
+			continue
+		}
+		for _, task := range taskList.Items {
+			blockedBy[task.ID] = task.BlockedBy
+			titles[task.ID] = task.Title
+		}
+	}
+
+	seen := make(map[int64]bool)
+	var path []string
+	var visit func(current int64) bool
+	visit = func(current int64) bool {
+		path = append(path, titles[current])
+		if current == id {
+			return true
+		}
+		if !seen[current] {
+			seen[current] = true
+			for _, next := range blockedBy[current] {
+				if visit(next) {
+					return true
+				}
+			}
+		}
+		path = path[:len(path)-1]
+		return false
+	}
+
+	if !visit(blocker.ID) {
+		return nil
+	}
+	return append([]string{titles[id]}, path...)
+}
+
+func addBlocker(taskList *TaskList, index int, blocker *Task) error {
+	if index < 1 || index > len(taskList.Items) {
+		return newError(errNotFound, "invalid task number. Use 1-%d", len(taskList.Items))
+	}
+
+	task := &taskList.Items[index-1]
+	if !slices.Contains(task.BlockedBy, blocker.ID) {
+		task.BlockedBy = append(task.BlockedBy, blocker.ID)
+	}
+	fmt.Fprintf(notices, "⛔ %s is blocked by %s\n", task.Title, blocker.Title)
+	return nil
+}
+
+// removeBlocker drops one blocker, or all of them when blocker is 0.
+func removeBlocker(taskList *TaskList, index int, blocker int64) error {
+	if index < 1 || index > len(taskList.Items) {
+		return newError(errNotFound, "invalid task number. Use 1-%d", len(taskList.Items))
+	}
+
+	task := &taskList.Items[index-1]
+	if blocker != 0 && !slices.Contains(task.BlockedBy, blocker) {
+		return newError(errNotFound, "'%s' is not blocked by that task", task.Title)
+	}
+	task.BlockedBy = slices.DeleteFunc(task.BlockedBy, func(id int64) bool {
+		return blocker == 0 || id == blocker
+	})
+	fmt.Fprintf(notices, "🔓 Unblocked: %s\n", task.Title)
+	return nil
+}
+
+// checkBlocker rejects blockers that are already done or would make the
+// task wait for itself.
+func checkBlocker(dir string, task *Task, blocker *Task) error {
+	if blocker.IsDone() {
+		return newError(errConflict, "'%s' is already completed", blocker.Title)
+	}
+	if cycle := dependencyCycle(dir, task.ID, blocker); cycle != nil {
+		return newError(errConflict, "dependency cycle: %s", strings.Join(cycle, " → "))
+	}
+	return nil
+}
+
+func handleBlock(config *Config) error {
+	listName, args := extractFlag(os.Args[2:], "--list")
+	blockerList, args := extractFlag(args, "--by-list")
+	if len(args) < 2 {
+		return newError(errInvalidArgument, "usage: tgo block <number|id> <blocker number|id> [--by-list <name>]")
+	}
+
+	taskFile, taskList, err := openTaskList(config, listName)
+	if err != nil {
+		return err
+	}
+	taskNum, err := resolveTask(taskList, args[0])
+	if err != nil {
+		return err
+	}
+
+	blockers := taskList
+	if blockerList != "" {
+		if _, blockers, err = openTaskList(config, blockerList); err != nil {
+			return err
+		}
+	}
+	blockerNum, err := resolveTask(blockers, args[1])
+	if err != nil {
+		return err
+	}
+
+	task, blocker := taskList.Items[taskNum-1], blockers.Items[blockerNum-1]
+	if err := checkBlocker(config.TaskDir, &task, &blocker); err != nil {
+		return err
+	}
+
+	err = updateTaskByID(taskFile, taskList, task.ID, func(taskList *TaskList, taskNum int) error {
+		return addBlocker(taskList, taskNum, &blocker)
+	})
+	if err != nil {
+		return err
+	}
+	return printTaskResult("block", taskFile, taskList, task.ID)
+}
+
+func handleUnblock(config *Config) error {
+	listName, args := extractFlag(os.Args[2:], "--list")
+	blockerList, args := extractFlag(args, "--by-list")
+	if len(args) < 1 {
+		return newError(errInvalidArgument, "usage: tgo unblock <number|id> [blocker number|id] [--by-list <name>]")
+	}
+
+	taskFile, taskList, err := openTaskList(config, listName)
+	if err != nil {
+		return err
+	}
+	taskNum, err := resolveTask(taskList, args[0])
+	if err != nil {
+		return err
+	}
+
+	var blockerID int64
+	if len(args) > 1 {
+		blockers := taskList
+		if blockerList != "" {
+			if _, blockers, err = openTaskList(config, blockerList); err != nil {
+				return err
+			}
+		}
+		blockerNum, err := resolveTask(blockers, args[1])
+		if err != nil {
+			return err
+		}
+		blockerID = blockers.Items[blockerNum-1].ID
+	}
+
+	id := taskList.Items[taskNum-1].ID
+	err = updateTaskByID(taskFile, taskList, id, func(taskList *TaskList, taskNum int) error {
+		return removeBlocker(taskList, taskNum, blockerID)
+	})
+	if err != nil {
+		return err
+	}
+	return printTaskResult("unblock", taskFile, taskList, id)
+}
+
+func handleBlockTask(arg string, taskList *TaskList, taskFile string) {
+	if err := blockTask(arg, taskList, taskFile); err != nil {
+		fmt.Printf("❌ %v\n", err)
+	}
+}
+
+func blockTask(arg string, taskList *TaskList, taskFile string) error {
+	taskRef, blockerRef, _ := strings.Cut(arg, " ")
+	taskNum, err := resolveTask(taskList, taskRef)
+	if err != nil {
+		return err
+	}
+	blockerNum, err := resolveTask(taskList, blockerRef)
+	if err != nil {
+		return err
+	}
+
+	task, blocker := taskList.Items[taskNum-1], taskList.Items[blockerNum-1]
+	if err := checkBlocker(filepath.Dir(taskFile), &task, &blocker); err != nil {
+		return err
+	}
+	return updateTaskByID(taskFile, taskList, task.ID, func(taskList *TaskList, taskNum int) error {
+		return addBlocker(taskList, taskNum, &blocker)
+	})
+}
+
+func handleUnblockTask(arg string, taskList *TaskList, taskFile string) {
+	taskRef, blockerRef, _ := strings.Cut(arg, " ")
+	var blockerID int64
+	if blockerRef = strings.TrimSpace(blockerRef); blockerRef != "" {
+		blockerNum, err := resolveTask(taskList, blockerRef)
+		if err != nil {
+			fmt.Printf("❌ %v\n", err)
+			return
+		}
+		blockerID = taskList.Items[blockerNum-1].ID
+	}
+
+	err := updateTask(taskFile, taskList, taskRef, func(taskList *TaskList, taskNum int) error {
+		return removeBlocker(taskList, taskNum, blockerID)
+	})
+	if err != nil {
+		fmt.Printf("❌ %v\n", err)
+	}
+}
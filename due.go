@@ -0,0 +1,227 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var weekdays = map[string]time.Weekday{
+	"sun": time.Sunday, "sunday": time.Sunday,
+	"mon": time.Monday, "monday": time.Monday,
+	"tue": time.Tuesday, "tuesday": time.Tuesday,
+	"wed": time.Wednesday, "wednesday": time.Wednesday,
+	"thu": time.Thursday, "thursday": time.Thursday,
+	"fri": time.Friday, "friday": time.Friday,
+	"sat": time.Saturday, "saturday": time.Saturday,
+}
+
+// parseDueDate understands today, tomorrow, weekday names (the next such
+// day, or today), YYYY-MM-DD and offsets like +3d, +2w or +1m.
+func parseDueDate(value string, now time.Time) (time.Time, error) {
+	today := startOfDay(now)
+	value = strings.ToLower(strings.TrimSpace(value))
+
+	if date, ok := parseDateWord(value, today); ok {
+		return date, nil
+	}
+	if weekday, ok := weekdays[value]; ok {
+		return today.AddDate(0, 0, (int(weekday)-int(today.Weekday())+7)%7), nil
+	}
+	if offset, ok := strings.CutPrefix(value, "+"); ok && len(offset) > 1 {
+		n, err := strconv.Atoi(offset[:len(offset)-1])
+		if err == nil && n >= 0 {
+			switch offset[len(offset)-1] {
+			case 'd':
+				return today.AddDate(0, 0, n), nil
+			case 'w':
+				return today.AddDate(0, 0, 7*n), nil
+			case 'm':
+				return today.AddDate(0, n, 0), nil
+			}
+		}
+	}
+	return time.Time{}, newError(errInvalidArgument, "invalid due date '%s', use today, tomorrow, a weekday, YYYY-MM-DD or +3d", value)
+}
+
+// taskDueInfo marks open tasks that are overdue, due today or have a
+// due date coming up.
+func taskDueInfo(task *Task, now time.Time) string {
+	if task.Due == nil || task.IsDone() {
+		return ""
+	}
+
+	today := startOfDay(now)
+	due := startOfDay(*task.Due)
+	switch {
+	case due.Before(today):
+		return fmt.Sprintf(" [🔥 Overdue: %s]", due.Format("Mon Jan 2"))
+	case due.Equal(today):
+		return " [📌 Due today]"
+	}
+	return fmt.Sprintf(" [Due: %s]", due.Format("Mon Jan 2"))
+}
+
+func setTaskDue(taskList *TaskList, index int, due *time.Time) error {
+	if index < 1 || index > len(taskList.Items) {
+		return newError(errNotFound, "invalid task number. Use 1-%d", len(taskList.Items))
+	}
+
+	task := &taskList.Items[index-1]
+	task.Due = due
+	if due == nil {
+		fmt.Fprintf(notices, "📅 Cleared due date: %s\n", task.Title)
+	} else {
+		fmt.Fprintf(notices, "📅 Due %s: %s\n", due.Format("Mon Jan 2"), task.Title)
+	}
+	return nil
+}
+
+func parseDueArg(value string) (*time.Time, error) {
+	if value == "" || value == "-" || strings.EqualFold(value, "none") {
+		return nil, nil
+	}
+	due, err := parseDueDate(value, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	return &due, nil
+}
+
+func handleDue(config *Config) error {
+	listName, args := extractFlag(os.Args[2:], "--list")
+	if len(args) < 1 {
+		return newError(errInvalidArgument, "usage: tgo due <number|id> <date|none>")
+	}
+
+	due, err := parseDueArg(strings.Join(args[1:], " "))
+	if err != nil {
+		return err
+	}
+
+	return runTaskCommand(config, "due", listName, args[0], func(taskList *TaskList, taskNum int) error {
+		return setTaskDue(taskList, taskNum, due)
+	})
+}
+
+func handleDueTask(arg string, taskList *TaskList, taskFile string) {
+	taskRef, value, _ := strings.Cut(arg, " ")
+	due, err := parseDueArg(value)
+	if err != nil {
+		fmt.Printf("❌ %v\n", err)
+		return
+	}
+
+	err = updateTask(taskFile, taskList, taskRef, func(taskList *TaskList, taskNum int) error {
+		return setTaskDue(taskList, taskNum, due)
+	})
+	if err != nil {
+		fmt.Printf("❌ %v\n", err)
+	}
+}
+
+type agendaItem struct {
+	List    string    `json:"list"`
+	ShortID string    `json:"short_id"`
+	Due     time.Time `json:"due"`
+	Task    Task      `json:"task"`
+}
+
+type agendaResult struct {
+	OK       bool         `json:"ok"`
+	Command  string       `json:"command"`
+	Overdue  []agendaItem `json:"overdue"`
+	Today    []agendaItem `json:"today"`
+	ThisWeek []agendaItem `json:"this_week"`
+	Later    []agendaItem `json:"later"`
+}
+
+func handleAgenda(config *Config) error {
+	if config.TaskDir == "" {
+		return errNoTaskDir
+	}
+
+	taskFiles, err := findTaskFiles(config.TaskDir)
+	if err != nil {
+		return err
+	}
+
+	var items []agendaItem
+	for _, file := range taskFiles {
+		taskList, err := loadTasks(filepath.Join(config.TaskDir, file))
+		if err != nil {
+			return newError(errIO, "cannot load %s: %v", file, err)
+		}
+		for i := range taskList.Items {
+			task := &taskList.Items[i]
+			if task.Due == nil || task.IsDone() {
+				continue
+			}
+			items = append(items, agendaItem{
+				List:    listDisplayName(taskList, file),
+				ShortID: shortID(taskList, task),
+				Due:     startOfDay(*task.Due),
+				Task:    *task,
+			})
+		}
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		if !items[i].Due.Equal(items[j].Due) {
+			return items[i].Due.Before(items[j].Due)
+		}
+		return priorityRank(items[i].Task.Priority) < priorityRank(items[j].Task.Priority)
+	})
+
+	today := startOfDay(time.Now())
+	weekEnd := today.AddDate(0, 0, 7-(int(today.Weekday())+6)%7)
+	result := agendaResult{OK: true, Command: "agenda",
+		Overdue: []agendaItem{}, Today: []agendaItem{}, ThisWeek: []agendaItem{}, Later: []agendaItem{}}
+	for _, item := range items {
+		switch {
+		case item.Due.Before(today):
+			result.Overdue = append(result.Overdue, item)
+		case item.Due.Equal(today):
+			result.Today = append(result.Today, item)
+		case item.Due.Before(weekEnd):
+			result.ThisWeek = append(result.ThisWeek, item)
+		default:
+			result.Later = append(result.Later, item)
+		}
+	}
+
+	if jsonOutput {
+		printJSON(result)
+		return nil
+	}
+
+	if len(items) == 0 {
+		fmt.Println("📅 Nothing due")
+		return nil
+	}
+	for _, group := range []struct {
+		title string
+		items []agendaItem
+	}{
+		{"🔥 OVERDUE", result.Overdue},
+		{"📌 TODAY", result.Today},
+		{"🗓️ THIS WEEK", result.ThisWeek},
+		{"⏭️ LATER", result.Later},
+	} {
+		if len(group.items) == 0 {
+			continue
+		}
+		fmt.Printf("\n%s:\n", group.title)
+		for _, item := range group.items {
+			statusIcon, _ := taskStatusInfo(&item.Task)
+			fmt.Printf("  %s  %s %s %s  (%s)\n", item.Due.Format("Mon Jan 02"),
+				item.ShortID, statusIcon, taskLabel(&item.Task), item.List)
+		}
+	}
+	fmt.Println()
+	return nil
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseDueDate(t *testing.T) {
+	// A Wednesday afternoon.
+	now := time.Date(2026, time.October, 14, 15, 30, 0, 0, time.Local)
+	date := func(month time.Month, day int) time.Time {
+		return time.Date(2026, month, day, 0, 0, 0, 0, time.Local)
+	}
+
+	tests := []struct {
+		value string
+		want  time.Time
+	}{
+		{"today", date(time.October, 14)},
+		{"Tomorrow", date(time.October, 15)},
+		{"wed", date(time.October, 14)},
+		{"fri", date(time.October, 16)},
+		{"monday", date(time.October, 19)},
+		{"tue", date(time.October, 20)},
+		{" 2026-11-01 ", date(time.November, 1)},
+		{"+0d", date(time.October, 14)},
+		{"+3d", date(time.October, 17)},
+		{"+2w", date(time.October, 28)},
+		{"+1m", date(time.November, 14)},
+	}
+	for _, test := range tests {
+		got, err := parseDueDate(test.value, now)
+		if err != nil {
+			t.Errorf("parseDueDate(%q) error: %v", test.value, err)
+			continue
+		}
+		if !got.Equal(test.want) {
+			t.Errorf("parseDueDate(%q) = %v, want %v", test.value, got, test.want)
+		}
+	}
+
+	for _, value := range []string{"", "soon", "+d", "+-1d", "+3y", "2026-13-01", "fri 10:00"} {
+		if got, err := parseDueDate(value, now); err == nil {
+			t.Errorf("parseDueDate(%q) = %v, want an error", value, got)
+		}
+	}
+}
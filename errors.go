@@ -0,0 +1,71 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+)
+
+type errorKind string
+
+const (
+	errGeneric         errorKind = "error"
+	errInvalidArgument errorKind = "invalid_argument"
+	errNotFound        errorKind = "not_found"
+	errIO              errorKind = "io_error"
+	errConflict        errorKind = "conflict"
+)
+
+type cliError struct {
+	kind     errorKind
+	err      error
+	reported bool
+}
+
+func (e *cliError) Error() string {
+	return e.err.Error()
+}
+
+func (e *cliError) Unwrap() error {
+	return e.err
+}
+
+func newError(kind errorKind, format string, args ...any) error {
+	return &cliError{kind: kind, err: fmt.Errorf(format, args...)}
+}
+
+// withKind tags err with kind unless it already carries one.
+func withKind(kind errorKind, err error) error {
+	var ce *cliError
+	if err == nil || errors.As(err, &ce) {
+		return err
+	}
+	return &cliError{kind: kind, err: err}
+}
+
+func exitCode(err error) int {
+	switch kindOf(err) {
+	case errInvalidArgument:
+		return 2
+	case errNotFound:
+		return 3
+	case errIO:
+		return 4
+	case errConflict:
+		return 5
+	}
+	return 1
+}
+
+func kindOf(err error) errorKind {
+	var ce *cliError
+	if errors.As(err, &ce) {
+		return ce.kind
+	}
+	return errGeneric
+}
+
+// reportedError carries an exit code for a failure whose details were
+// already printed, so runCLI does not report it a second time.
+func reportedError(kind errorKind, format string, args ...any) error {
+	return &cliError{kind: kind, err: fmt.Errorf(format, args...), reported: true}
+}
@@ -0,0 +1,302 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// exportSessions collects the recorded sessions matching the --from, --to
+// and --list flags. Without a range every session is exported.
+func exportSessions(config *Config, args []string) ([]sessionEntry, error) {
+	fromSpec, args := extractFlag(args, "--from")
+	toSpec, args := extractFlag(args, "--to")
+	listName, _ := extractFlag(args, "--list")
+
+	now := time.Now()
+	from, to := time.Time{}, time.Date(9999, 1, 1, 0, 0, 0, 0, time.UTC)
+	if fromSpec != "" || toSpec != "" {
+		var err error
+		if from, to, err = parseRange(fromSpec, toSpec, now); err != nil {
+			return nil, err
+		}
+	}
+
+	entries, err := collectSessions(config, listName, from, to, now)
+	if err != nil {
+		return nil, err
+	}
+
+	recorded := entries[:0]
+	for _, entry := range entries {
+		if !entry.Running {
+			recorded = append(recorded, entry)
+		}
+	}
+	return recorded, nil
+}
+
+func exportCSV(config *Config, args []string) ([]byte, int, error) {
+	roundSpec, args := extractFlag(args, "--round")
+	entries, err := exportSessions(config, args)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var unit time.Duration
+	if roundSpec != "" {
+		if unit, err = parseDurationArg(roundSpec); err != nil {
+			return nil, 0, err
+		}
+		if unit <= 0 {
+			return nil, 0, newError(errInvalidArgument, "--round must be positive")
+		}
+	}
+
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	w.Write([]string{"list", "task", "task_id", "start", "end", "duration_seconds", "status"})
+	for _, entry := range entries {
+		duration := entry.End.Sub(entry.Start)
+		if unit > 0 {
+			duration = duration.Round(unit)
+		}
+		w.Write([]string{
+			entry.List,
+			entry.Task.Title,
+			strconv.FormatInt(entry.Task.ID, 10),
+			entry.Start.Local().Format(time.RFC3339),
+			entry.End.Local().Format(time.RFC3339),
+			strconv.FormatInt(int64(duration.Seconds()), 10),
+			string(entry.Task.Status),
+		})
+	}
+	w.Flush()
+	return buf.Bytes(), len(entries), w.Error()
+}
+
+// exportICS writes one VEVENT per session. UIDs are derived from the task
+// ID and session start, so calendar apps update events on re-import.
+func exportICS(config *Config, args []string) ([]byte, int, error) {
+	entries, err := exportSessions(config, args)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var buf bytes.Buffer
+	line := func(format string, args ...any) {
+		buf.WriteString(foldICSLine(fmt.Sprintf(format, args...)))
+		buf.WriteString("\r\n")
+	}
+
+	stamp := time.Now().UTC().Format(icsTime)
+	line("BEGIN:VCALENDAR")
+	line("VERSION:2.0")
+	line("PRODID:-//tgo//Task CLI Manager//EN")
+	line("CALSCALE:GREGORIAN")
+	line("X-WR-CALNAME:tgo")
+	for _, entry := range entries {
+		description := "List: " + entry.List
+		if entry.Task.Comment != "" {
+			description += "\n\n" + entry.Task.Comment
+		}
+
+		line("BEGIN:VEVENT")
+		line("UID:%d-%d@tgo", entry.Task.ID, entry.Start.Unix())
+		line("DTSTAMP:%s", stamp)
+		line("DTSTART:%s", entry.Start.UTC().Format(icsTime))
+		line("DTEND:%s", entry.End.UTC().Format(icsTime))
+		line("SUMMARY:%s", escapeICSText(entry.Task.Title))
+		line("DESCRIPTION:%s", escapeICSText(description))
+		line("CATEGORIES:%s", escapeICSText(entry.List))
+		line("END:VEVENT")
+	}
+	line("END:VCALENDAR")
+	return buf.Bytes(), len(entries), nil
+}
+
+const icsTime = "20060102T150405Z"
+
+func escapeICSText(text string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`).Replace(text)
+}
+
+// foldICSLine splits content lines longer than 75 octets as RFC 5545
+// requires, without breaking UTF-8 sequences.
+func foldICSLine(line string) string {
+	var b strings.Builder
+	width := 0
+	for _, r := range line {
+		size := utf8.RuneLen(r)
+		if width+size > 75 {
+			b.WriteString("\r\n ")
+			width = 1
+		}
+		b.WriteRune(r)
+		width += size
+	}
+	return b.String()
+}
+
+func exportMarkdown(config *Config, args []string) ([]byte, int, error) {
+	listName, args := extractFlag(args, "--list")
+	if listName == "" && len(args) > 0 {
+		listName = strings.Join(args, " ")
+	}
+	_, taskList, err := openTaskList(config, listName)
+	if err != nil {
+		return nil, 0, err
+	}
+	return markdownChecklist(taskList), len(taskList.Items), nil
+}
+
+// markdownChecklist writes a list as a GitHub-flavoured checklist that
+// tgo import md reads back: tags and tracked time follow the title, and
+// subtasks and notes are indented under their task. The tracked time is
+// for reading only and is not imported.
+func markdownChecklist(taskList *TaskList) []byte {
+	var buf bytes.Buffer
+	var writeTask func(task *Task, depth int)
+	writeTask = func(task *Task, depth int) {
+		indent := strings.Repeat("  ", depth)
+		mark := " "
+		if task.IsDone() {
+			mark = "x"
+		}
+		fmt.Fprintf(&buf, "%s- [%s] %s", indent, mark, task.Title)
+		for _, tag := range task.Tags {
+			fmt.Fprintf(&buf, " %s", tagWord(tag))
+		}
+		if task.TotalDuration > 0 {
+			fmt.Fprintf(&buf, " `⏱ %s`", task.GetFormattedDuration())
+		}
+		buf.WriteString("\n")
+
+		if task.Comment != "" {
+			for _, line := range strings.Split(task.Comment, "\n") {
+				if line == "" {
+					buf.WriteString("\n")
+					continue
+				}
+				fmt.Fprintf(&buf, "%s  %s\n", indent, line)
+			}
+		}
+
+		for i := range taskList.Items {
+			if taskList.Items[i].ParentID == task.ID && depth < len(taskList.Items) {
+				writeTask(&taskList.Items[i], depth+1)
+			}
+		}
+	}
+
+	fmt.Fprintf(&buf, "# %s\n\n", taskList.Title)
+	for i := range taskList.Items {
+		if parentIndex(taskList, &taskList.Items[i]) < 0 {
+			writeTask(&taskList.Items[i], 0)
+		}
+	}
+	return buf.Bytes()
+}
+
+func exportTodoTxt(config *Config, args []string) ([]byte, int, error) {
+	listName, args := extractFlag(args, "--list")
+	if listName == "" && len(args) > 0 {
+		listName = strings.Join(args, " ")
+	}
+	_, taskList, err := openTaskList(config, listName)
+	if err != nil {
+		return nil, 0, err
+	}
+	return todoTxtLines(taskList), len(taskList.Items), nil
+}
+
+// todoTxtLines writes a list in todo.txt format. Notes and tracked time
+// have no todo.txt equivalent and are left out.
+func todoTxtLines(taskList *TaskList) []byte {
+	var buf bytes.Buffer
+	for _, task := range taskList.Items {
+		var fields []string
+		switch {
+		case task.IsDone():
+			fields = append(fields, "x")
+			if task.CompletedAt != nil {
+				fields = append(fields, task.CompletedAt.Local().Format("2006-01-02"))
+			}
+		case task.Priority != "":
+			fields = append(fields, "("+task.Priority+")")
+		}
+		if !task.CreatedAt.IsZero() {
+			fields = append(fields, task.CreatedAt.Local().Format("2006-01-02"))
+		}
+		fields = append(fields, strings.Join(strings.Fields(task.Title), " "))
+		if task.IsDone() && task.Priority != "" {
+			fields = append(fields, "pri:"+task.Priority)
+		}
+		for _, tag := range task.Tags {
+			if !strings.HasPrefix(tag, "@") {
+				tag = "+" + tag
+			}
+			fields = append(fields, tag)
+		}
+		if task.Due != nil {
+			fields = append(fields, "due:"+task.Due.Local().Format("2006-01-02"))
+		}
+		fmt.Fprintln(&buf, strings.Join(fields, " "))
+	}
+	return buf.Bytes()
+}
+
+func handleExport(config *Config) error {
+	format, args := extractFlag(os.Args[2:], "--format")
+	output, args := extractFlag(args, "--output")
+	if format == "" && len(args) > 0 {
+		format, args = args[0], args[1:]
+	}
+
+	var data []byte
+	var count int
+	var err error
+	unit := "session(s)"
+	switch format {
+	case "csv":
+		data, count, err = exportCSV(config, args)
+	case "ics":
+		data, count, err = exportICS(config, args)
+	case "md", "markdown":
+		data, count, err = exportMarkdown(config, args)
+		unit = "task(s)"
+	case "todotxt", "todo.txt":
+		data, count, err = exportTodoTxt(config, args)
+		unit = "task(s)"
+	case "":
+		return newError(errInvalidArgument, "export format required, use --format csv|ics|md|todotxt")
+	default:
+		return newError(errInvalidArgument, "unsupported export format '%s', use csv, ics, md or todotxt", format)
+	}
+	if err != nil {
+		return err
+	}
+
+	if output == "" {
+		if jsonOutput {
+			return newError(errInvalidArgument, "--json export needs --output <file>")
+		}
+		_, err := os.Stdout.Write(data)
+		return withKind(errIO, err)
+	}
+
+	if err := os.WriteFile(output, data, 0644); err != nil {
+		return newError(errIO, "cannot write %s: %v", output, err)
+	}
+	if jsonOutput {
+		printJSON(commandResult{OK: true, Command: "export", Path: output})
+		return nil
+	}
+	fmt.Printf("📤 Exported %d %s to %s\n", count, unit, output)
+	return nil
+}
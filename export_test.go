@@ -0,0 +1,75 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMarkdownRoundTrip(t *testing.T) {
+	hour := time.Hour.Nanoseconds()
+	tests := []struct {
+		name  string
+		items []Task
+		want  []importedTask
+	}{
+		{
+			name:  "plain",
+			items: []Task{{ID: 1, Title: "Write report", Status: StatusPending}},
+			want:  []importedTask{{Title: "Write report"}},
+		},
+		{
+			name:  "done with tags",
+			items: []Task{{ID: 1, Title: "Ship it", Status: StatusDone, Tags: []string{"client-a", "@office"}}},
+			want:  []importedTask{{Title: "Ship it", Done: true, Tags: []string{"client-a", "@office"}}},
+		},
+		{
+			name:  "tracked time is dropped",
+			items: []Task{{ID: 1, Title: "Review", Status: StatusPaused, TotalDuration: 3 * hour / 2}},
+			want:  []importedTask{{Title: "Review"}},
+		},
+		{
+			name:  "note",
+			items: []Task{{ID: 1, Title: "Plan", Status: StatusPending, Comment: "first line\n\nafter a gap"}},
+			want:  []importedTask{{Title: "Plan", Comment: "first line\n\nafter a gap"}},
+		},
+		{
+			name: "nested subtasks",
+			items: []Task{
+				{ID: 1, Title: "Release", Status: StatusPending},
+				{ID: 2, Title: "Build", Status: StatusDone, ParentID: 1},
+				{ID: 3, Title: "Sign", Status: StatusPending, ParentID: 2, Comment: "with the new key"},
+				{ID: 4, Title: "Announce", Status: StatusPending, ParentID: 1},
+				{ID: 5, Title: "Rest", Status: StatusPending},
+			},
+			want: []importedTask{
+				{Title: "Release"},
+				{Title: "Build", Done: true, Parent: 1},
+				{Title: "Sign", Parent: 2, Comment: "with the new key"},
+				{Title: "Announce", Parent: 1},
+				{Title: "Rest"},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			text := string(markdownChecklist(&TaskList{Title: "Work", Items: test.items}))
+			title, got := parseMarkdown(text)
+			if title != "Work" {
+				t.Errorf("title = %q, want %q", title, "Work")
+			}
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("round trip of\n%s= %+v, want %+v", text, got, test.want)
+			}
+		})
+	}
+}
+
+func TestMarkdownChecklistShowsTrackedTime(t *testing.T) {
+	taskList := &TaskList{Title: "Work", Items: []Task{{ID: 1, Title: "Review", Status: StatusPaused, TotalDuration: time.Hour.Nanoseconds()}}}
+	if text := string(markdownChecklist(taskList)); !strings.Contains(text, "- [ ] Review `⏱ ") {
+		t.Errorf("markdownChecklist = %q, want the tracked time after the title", text)
+	}
+}
@@ -1,11 +1,79 @@
 package main
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
 )
 
+const backupSuffix = ".bak"
+
+func writeFileAtomic(filePath string, data []byte, perm os.FileMode) error {
+	if previous, err := os.ReadFile(filePath); err == nil && json.Valid(previous) {
+		if err := replaceFile(filePath+backupSuffix, previous, perm); err != nil {
+			return fmt.Errorf("cannot write backup: %v", err)
+		}
+	}
+	return replaceFile(filePath, data, perm)
+}
+
+func replaceFile(filePath string, data []byte, perm os.FileMode) error {
+	dir := filepath.Dir(filePath)
+	tmpFile, err := os.CreateTemp(dir, "."+filepath.Base(filePath)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	tmpName := tmpFile.Name()
+	defer os.Remove(tmpName)
+
+	if _, err := tmpFile.Write(data); err != nil {
+		tmpFile.Close()
+		return err
+	}
+	if err := tmpFile.Sync(); err != nil {
+		tmpFile.Close()
+		return err
+	}
+	if err := tmpFile.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmpName, perm); err != nil {
+		return err
+	}
+	if err := os.Rename(tmpName, filePath); err != nil {
+		return err
+	}
+
+	if d, err := os.Open(dir); err == nil {
+		d.Sync()
+		d.Close()
+	}
+	return nil
+}
+
+func lockPath(filePath string) string {
+	return filepath.Join(filepath.Dir(filePath), "."+filepath.Base(filePath)+".lock")
+}
+
+func removeListFile(filePath string) error {
+	if err := os.Remove(filePath); err != nil {
+		return err
+	}
+	os.Remove(filePath + backupSuffix)
+	os.Remove(lockPath(filePath))
+	return nil
+}
+
+func readJSONFile(filePath string, v any) error {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
 func showDirContents(folder string) {
 	entries, err := os.ReadDir(folder)
 	if err != nil {
@@ -17,6 +85,9 @@ func showDirContents(folder string) {
 	var otherFiles []string
 	
 	for _, entry := range entries {
+		if strings.HasPrefix(entry.Name(), ".") || strings.HasSuffix(entry.Name(), backupSuffix) {
+			continue
+		}
 		if !entry.IsDir() {
 			if strings.HasSuffix(entry.Name(), ".json") {
 				taskFiles = append(taskFiles, entry.Name())
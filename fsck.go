@@ -0,0 +1,270 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+type listIssue struct {
+	TaskID  int64  `json:"task_id"`
+	Task    string `json:"task"`
+	Problem string `json:"problem"`
+}
+
+type fsckResult struct {
+	File     string      `json:"file"`
+	Issues   []listIssue `json:"issues"`
+	Repaired bool        `json:"repaired"`
+}
+
+type fsckReport struct {
+	OK      bool         `json:"ok"`
+	Command string       `json:"command"`
+	Lists   []fsckResult `json:"lists"`
+}
+
+var warnedFiles = map[string]bool{}
+
+// warnInvalid prints a one-time warning when a loaded list fails validation.
+func warnInvalid(filePath string, taskList *TaskList) {
+	if warnedFiles[filePath] {
+		return
+	}
+	issues := validateTaskList(taskList)
+	if len(issues) == 0 {
+		return
+	}
+	warnedFiles[filePath] = true
+	fmt.Fprintf(warnings, "⚠️ %s has %d problem(s), first: %s: %s (run 'tgo fsck')\n",
+		filepath.Base(filePath), len(issues), issues[0].Task, issues[0].Problem)
+}
+
+func validateTaskList(taskList *TaskList) []listIssue {
+	var issues []listIssue
+	report := func(task *Task, format string, args ...any) {
+		issues = append(issues, listIssue{TaskID: task.ID, Task: task.Title, Problem: fmt.Sprintf(format, args...)})
+	}
+
+	var running []*Task
+	for i := range taskList.Items {
+		task := &taskList.Items[i]
+
+		var total int64
+		for n, session := range task.Sessions {
+			length := session.EndTime.Sub(session.StartTime).Nanoseconds()
+			if length < 0 {
+				report(task, "session %d ends before it starts", n+1)
+			} else if session.Duration != length {
+				report(task, "session %d duration is %s off its start and end",
+					n+1, time.Duration(session.Duration-length))
+			}
+			total += length
+		}
+		if total != task.TotalDuration {
+			report(task, "total %s is %s off its sessions",
+				formatDuration(task.TotalDuration), time.Duration(task.TotalDuration-total))
+		}
+
+		var reach time.Time
+		for n, index := range sessionOrder(task.Sessions) {
+			start, end := sessionSpan(task.Sessions[index])
+			if n > 0 && start.Before(reach) {
+				report(task, "session %d overlaps an earlier session", index+1)
+			}
+			if end.After(reach) {
+				reach = end
+			}
+		}
+
+		if task.Status == StatusActive {
+			if task.ActiveStartTime == nil {
+				report(task, "active without a start time")
+			} else {
+				running = append(running, task)
+			}
+		}
+	}
+
+	if len(running) > 1 {
+		latest := latestStarted(running)
+		for _, task := range running {
+			if task != latest {
+				report(task, "running at the same time as %s", latest.Title)
+			}
+		}
+	}
+	return issues
+}
+
+// repairTaskList fixes everything validateTaskList reports: reversed
+// sessions are swapped, overlapping ones merged, extra timers stopped
+// when the most recent one started, and totals recomputed.
+func repairTaskList(taskList *TaskList) {
+	var running []*Task
+	for i := range taskList.Items {
+		task := &taskList.Items[i]
+		if task.Status != StatusActive {
+			continue
+		}
+		if task.ActiveStartTime != nil {
+			running = append(running, task)
+			continue
+		}
+		task.Status = StatusPending
+		if len(task.Sessions) > 0 {
+			task.Status = StatusPaused
+		}
+	}
+
+	if len(running) > 1 {
+		latest := latestStarted(running)
+		for _, task := range running {
+			if task != latest {
+				// Closed when the later timer started, untouched by idle trimming.
+				task.Sessions = append(task.Sessions, Session{StartTime: *task.ActiveStartTime, EndTime: *latest.ActiveStartTime})
+				task.Status = StatusPaused
+				task.ActiveStartTime = nil
+			}
+		}
+	}
+
+	for i := range taskList.Items {
+		task := &taskList.Items[i]
+		var sessions []Session
+		for _, n := range sessionOrder(task.Sessions) {
+			start, end := sessionSpan(task.Sessions[n])
+			if last := len(sessions) - 1; last >= 0 && start.Before(sessions[last].EndTime) {
+				if end.After(sessions[last].EndTime) {
+					sessions[last].EndTime = end
+				}
+				continue
+			}
+			sessions = append(sessions, Session{StartTime: start, EndTime: end})
+		}
+		task.Sessions = sessions
+		recomputeTotal(task)
+	}
+}
+
+// sessionOrder returns session indexes sorted by start time, treating
+// reversed sessions as if their bounds were swapped.
+func sessionOrder(sessions []Session) []int {
+	order := make([]int, len(sessions))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		startA, _ := sessionSpan(sessions[order[a]])
+		startB, _ := sessionSpan(sessions[order[b]])
+		return startA.Before(startB)
+	})
+	return order
+}
+
+func sessionSpan(session Session) (time.Time, time.Time) {
+	if session.EndTime.Before(session.StartTime) {
+		return session.EndTime, session.StartTime
+	}
+	return session.StartTime, session.EndTime
+}
+
+func latestStarted(tasks []*Task) *Task {
+	latest := tasks[0]
+	for _, task := range tasks[1:] {
+		if task.ActiveStartTime.After(*latest.ActiveStartTime) {
+			latest = task
+		}
+	}
+	return latest
+}
+
+func handleFsck(config *Config) error {
+	listName, args := extractFlag(os.Args[2:], "--list")
+	repair, _ := extractBoolFlag(args, "--repair")
+
+	if config.TaskDir == "" {
+		return errNoTaskDir
+	}
+
+	var taskFiles []string
+	if listName != "" {
+		taskFile, err := findListFile(config.TaskDir, listName)
+		if err != nil {
+			return err
+		}
+		taskFiles = append(taskFiles, taskFile)
+	} else {
+		files, err := findTaskFiles(config.TaskDir)
+		if err != nil {
+			return err
+		}
+		for _, file := range files {
+			taskFiles = append(taskFiles, filepath.Join(config.TaskDir, file))
+		}
+	}
+
+	warnings = io.Discard
+	report := fsckReport{Command: "fsck", Lists: []fsckResult{}}
+	remaining := 0
+	for _, taskFile := range taskFiles {
+		taskList, err := loadTasks(taskFile)
+		if err != nil {
+			return newError(errIO, "cannot load %s: %v", filepath.Base(taskFile), err)
+		}
+
+		result := fsckResult{File: filepath.Base(taskFile), Issues: validateTaskList(taskList)}
+		if result.Issues == nil {
+			result.Issues = []listIssue{}
+		}
+		if repair && len(result.Issues) > 0 {
+			// Saved without updateTasks so the sessions repair closes are
+			// never trimmed or asked about.
+			err := modifyTasks(taskFile, taskList, func(taskList *TaskList) error {
+				repairTaskList(taskList)
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+			if err := syncRunningTimer(taskFile, taskList, 0, nil); err != nil {
+				return err
+			}
+			result.Repaired = true
+		} else {
+			remaining += len(result.Issues)
+		}
+		report.Lists = append(report.Lists, result)
+	}
+	report.OK = remaining == 0
+
+	if jsonOutput {
+		printJSON(report)
+		if remaining > 0 {
+			return reportedError(errGeneric, "%d problem(s) found", remaining)
+		}
+		return nil
+	}
+
+	for _, result := range report.Lists {
+		if len(result.Issues) == 0 {
+			fmt.Printf("✅ %s: OK\n", result.File)
+			continue
+		}
+		fmt.Printf("⚠️ %s: %d problem(s)\n", result.File, len(result.Issues))
+		for _, issue := range result.Issues {
+			fmt.Printf("   - %s: %s\n", issue.Task, issue.Problem)
+		}
+		if result.Repaired {
+			fmt.Println("   🔧 Repaired")
+		}
+	}
+
+	if remaining > 0 {
+		return newError(errGeneric, "%d problem(s) found, run 'tgo fsck --repair' to fix them", remaining)
+	}
+	return nil
+}
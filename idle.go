@@ -0,0 +1,309 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const (
+	idleTrim = "trim"
+	idleKeep = "keep"
+)
+
+// Set from Config by configureIdle; a zero maxSession disables the check.
+var (
+	maxSession time.Duration
+	idlePolicy = idleTrim
+)
+
+// askSessionEnd picks the end of a session that ran past maxSession;
+// runTUI swaps in a version that restores the terminal while it asks.
+var askSessionEnd = promptSessionEnd
+
+type idleResult struct {
+	OK         bool   `json:"ok"`
+	Command    string `json:"command"`
+	MaxSession string `json:"max_session"`
+	Policy     string `json:"policy"`
+}
+
+var activity struct {
+	previous, current time.Time
+}
+
+// noteActivity records that the user just did something: a command was
+// run or, in interactive mode, a key or line was entered.
+func noteActivity() {
+	activity.previous, activity.current = activity.current, time.Now()
+}
+
+func configureIdle(config *Config) {
+	if config.IdlePolicy != "" {
+		idlePolicy = config.IdlePolicy
+	}
+	if config.MaxSession == "" {
+		return
+	}
+
+	limit, err := time.ParseDuration(config.MaxSession)
+	if err != nil || limit <= 0 {
+		fmt.Fprintf(warnings, "⚠️ Ignoring invalid max_session %q in %s\n", config.MaxSession, configFile)
+		return
+	}
+	maxSession = limit
+}
+
+// idleEnd is where an over-long session is cut when nobody says
+// otherwise: the last input seen during the session or, without any, the
+// session's start plus maxSession.
+func idleEnd(start, end time.Time) time.Time {
+	if activity.previous.After(start) && activity.previous.Before(end) {
+		return activity.previous
+	}
+	return start.Add(maxSession)
+}
+
+// policyEnd returns the end idlePolicy gives a session stopped at end.
+func policyEnd(start, end time.Time) time.Time {
+	if maxSession == 0 || end.Sub(start) <= maxSession || idlePolicy == idleKeep {
+		return end
+	}
+	return idleEnd(start, end)
+}
+
+// sessionEnds maps the IDs of tasks whose over-long session a change
+// stops to the end chosen for it; a zero time keeps the session whole.
+type sessionEnds map[int64]time.Time
+
+// choose asks for the end of task's session, stopped now, or falls back
+// to idlePolicy when nobody can answer.
+func (ends sessionEnds) choose(task *Task, now time.Time) {
+	start := *task.ActiveStartTime
+	if now.Sub(start) <= maxSession {
+		return
+	}
+
+	end := policyEnd(start, now)
+	if !jsonOutput && isTerminal(os.Stdin) {
+		end = askSessionEnd(task, start, now, idleEnd(start, now))
+	}
+	if end.Equal(now) {
+		end = time.Time{}
+	}
+	ends[task.ID] = end
+}
+
+// planSessionEnds runs apply on a copy of the list to find the over-long
+// sessions it would stop, here or in the list whose timer a newly started
+// task pauses, and chooses their ends before any lock is taken.
+func planSessionEnds(filePath string, taskList *TaskList, apply func(*TaskList) error) sessionEnds {
+	if maxSession == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	long := make(map[int64]*Task)
+	for i := range taskList.Items {
+		task := &taskList.Items[i]
+		if task.ActiveStartTime != nil && now.Sub(*task.ActiveStartTime) > maxSession {
+			long[task.ID] = task
+		}
+	}
+	other := otherRunningTask(filePath, now)
+	if len(long) == 0 && other == nil {
+		return nil
+	}
+
+	preview, err := cloneTaskList(taskList)
+	if err != nil {
+		return nil
+	}
+	quiet := notices
+	notices = io.Discard
+	err = apply(preview)
+	notices = quiet
+	if err != nil {
+		return nil
+	}
+
+	ends := make(sessionEnds)
+	before := activeTaskIDs(taskList)
+	started := false
+	for i := range preview.Items {
+		task := &preview.Items[i]
+		if original, ok := long[task.ID]; ok && task.ActiveStartTime == nil {
+			ends.choose(original, now)
+		}
+		started = started || task.IsActive() && !before[task.ID]
+	}
+	if started && other != nil {
+		ends.choose(other, now)
+	}
+	return ends
+}
+
+// otherRunningTask returns the task the state file records as running in
+// another list when its session is already over maxSession.
+func otherRunningTask(filePath string, now time.Time) *Task {
+	dir, listFile := filepath.Dir(filePath), filepath.Base(filePath)
+	running := loadState(filepath.Join(dir, stateFile)).Running
+	if running == nil || running.List == listFile {
+		return nil
+	}
+
+	taskList, err := loadTasks(filepath.Join(dir, running.List))
+	if err != nil {
+		return nil
+	}
+	taskNum, err := findTaskByID(taskList, running.TaskID)
+	if err != nil {
+		return nil
+	}
+	task := &taskList.Items[taskNum-1]
+	if task.ActiveStartTime == nil || now.Sub(*task.ActiveStartTime) <= maxSession {
+		return nil
+	}
+	return task
+}
+
+func cloneTaskList(taskList *TaskList) (*TaskList, error) {
+	data, err := json.Marshal(taskList)
+	if err != nil {
+		return nil, err
+	}
+	var clone TaskList
+	if err := json.Unmarshal(data, &clone); err != nil {
+		return nil, err
+	}
+	return &clone, nil
+}
+
+// activeStarts returns the start of every running session in the list.
+func activeStarts(taskList *TaskList) map[int64]time.Time {
+	starts := make(map[int64]time.Time)
+	for _, task := range taskList.Items {
+		if task.ActiveStartTime != nil {
+			starts[task.ID] = *task.ActiveStartTime
+		}
+	}
+	return starts
+}
+
+// trimSessions cuts the sessions a change just stopped to the ends chosen
+// beforehand, or by idlePolicy for any that were not planned. Cuts under a
+// second are left alone.
+func trimSessions(taskList *TaskList, starts map[int64]time.Time, ends sessionEnds) {
+	for id, start := range starts {
+		taskNum, err := findTaskByID(taskList, id)
+		if err != nil {
+			continue
+		}
+		task := &taskList.Items[taskNum-1]
+		if task.ActiveStartTime != nil || len(task.Sessions) == 0 {
+			continue
+		}
+		session := &task.Sessions[len(task.Sessions)-1]
+		if !session.StartTime.Equal(start) {
+			continue
+		}
+
+		end, planned := ends[id]
+		if !planned {
+			end = policyEnd(start, session.EndTime)
+		}
+		if end.IsZero() || !end.After(start) || session.EndTime.Sub(end) < time.Second {
+			continue
+		}
+
+		fmt.Fprintf(notices, "✂️ Trimmed session of %s from %s to %s\n",
+			task.Title, formatDuration(session.Duration), formatDuration(end.Sub(start).Nanoseconds()))
+		session.EndTime = end
+		session.Duration = end.Sub(start).Nanoseconds()
+		recomputeTotal(task)
+	}
+}
+
+func promptSessionEnd(task *Task, start, end, trimmed time.Time) time.Time {
+	fmt.Printf("\n⚠️ %s has been running for %s (limit %s)\n",
+		task.Title, formatDuration(end.Sub(start).Nanoseconds()), formatDuration(maxSession.Nanoseconds()))
+	fmt.Printf("   [t]rim to %s, [k]eep, or enter the time you stopped: ", trimmed.Format("Jan 2 15:04"))
+
+	for {
+		line, ok := readLine()
+		input := strings.TrimSpace(line)
+		switch strings.ToLower(input) {
+		case "", "t", "trim":
+			return trimmed
+		case "k", "keep":
+			return end
+		}
+		if !ok {
+			return trimmed
+		}
+
+		at, err := parseTimeSpec(input, end)
+		if err == nil && at.After(start) && !at.After(end) {
+			return at
+		}
+		fmt.Printf("❌ Enter a time between %s and %s: ", start.Format("Jan 2 15:04"), end.Format("Jan 2 15:04"))
+	}
+}
+
+func handleSetIdle(config *Config) error {
+	policy, args := extractFlag(os.Args[2:], "--policy")
+	if policy != "" && policy != idleTrim && policy != idleKeep {
+		return newError(errInvalidArgument, "policy must be '%s' or '%s'", idleTrim, idleKeep)
+	}
+
+	if len(args) == 0 && policy == "" {
+		if jsonOutput {
+			printJSON(idleResult{OK: true, Command: "set-idle", MaxSession: config.MaxSession, Policy: idlePolicy})
+			return nil
+		}
+		if config.MaxSession == "" {
+			fmt.Println("⏰ No session limit set")
+			return nil
+		}
+		fmt.Printf("⏰ Session limit: %s (policy when not asked: %s)\n", config.MaxSession, idlePolicy)
+		return nil
+	}
+
+	if len(args) > 0 {
+		if args[0] == "off" {
+			config.MaxSession = ""
+		} else {
+			limit, err := parseDurationArg(args[0])
+			if err != nil {
+				return err
+			}
+			if limit <= 0 {
+				return newError(errInvalidArgument, "session limit must be positive")
+			}
+			config.MaxSession = limit.String()
+		}
+	}
+	if policy != "" {
+		config.IdlePolicy = policy
+	}
+	configureIdle(config)
+
+	if err := saveConfig(config); err != nil {
+		return newError(errIO, "save error: %v", err)
+	}
+
+	if jsonOutput {
+		printJSON(idleResult{OK: true, Command: "set-idle", MaxSession: config.MaxSession, Policy: idlePolicy})
+		return nil
+	}
+	if config.MaxSession == "" {
+		fmt.Println("✅ Session limit turned off")
+		return nil
+	}
+	fmt.Printf("✅ Session limit set to %s (policy when not asked: %s)\n", config.MaxSession, idlePolicy)
+	return nil
+}
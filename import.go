@@ -0,0 +1,259 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// importedTask is a task read from another format, before it is added
+// to a list.
+type importedTask struct {
+	Title       string
+	Priority    string
+	Due         *time.Time
+	Tags        []string
+	Parent      int // 1-based position of the parent task, 0 for none
+	Done        bool
+	Comment     string
+	CreatedAt   time.Time
+	CompletedAt time.Time
+}
+
+var (
+	checklistItem = regexp.MustCompile(`^(\s*)[-*+]\s+\[([ xX])\]\s+(.*)$`)
+	durationTag   = regexp.MustCompile("\\s*`⏱[^`]*`$")
+	todoPriority  = regexp.MustCompile(`^\(([A-Z])\)\s+`)
+)
+
+// parseMarkdown reads GitHub-style checklist items. Items indented under
+// an item become its subtasks and other indented lines its note; the
+// first top-level heading names the list.
+func parseMarkdown(text string) (string, []importedTask) {
+	var title string
+	var tasks []importedTask
+	var note []string
+	var parents, depths []int
+	indent := -1
+
+	finish := func() {
+		if len(tasks) > 0 {
+			tasks[len(tasks)-1].Comment = strings.TrimRight(strings.Join(note, "\n"), "\n")
+		}
+		note, indent = nil, -1
+	}
+
+	for _, line := range strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n") {
+		if match := checklistItem.FindStringSubmatch(line); match != nil {
+			finish()
+			depth := len(match[1])
+			for len(parents) > 0 && depths[parents[len(parents)-1]-1] >= depth {
+				parents = parents[:len(parents)-1]
+			}
+			parent := 0
+			if len(parents) > 0 {
+				parent = parents[len(parents)-1]
+			}
+
+			words, tags := splitTags(strings.Fields(durationTag.ReplaceAllString(match[3], "")))
+			tasks = append(tasks, importedTask{
+				Title:  strings.Join(words, " "),
+				Tags:   tags,
+				Parent: parent,
+				Done:   match[2] != " ",
+			})
+			depths = append(depths, depth)
+			parents = append(parents, len(tasks))
+			indent = depth + 2
+			continue
+		}
+
+		if indent >= 0 {
+			if strings.TrimSpace(line) == "" {
+				note = append(note, "")
+				continue
+			}
+			if prefix := line[:len(line)-len(strings.TrimLeft(line, " \t"))]; len(prefix) >= indent {
+				note = append(note, line[indent:])
+				continue
+			}
+		}
+		finish()
+
+		if heading, ok := strings.CutPrefix(line, "# "); ok && title == "" {
+			title = strings.TrimSpace(heading)
+		}
+	}
+	finish()
+	return title, tasks
+}
+
+// parseTodoTxt reads one task per line in todo.txt format. Projects,
+// contexts and #tags become tags, due: becomes the due date and pri:,
+// which completed tasks use to keep their priority, is restored; other
+// extensions stay part of the title.
+func parseTodoTxt(text string) []importedTask {
+	var tasks []importedTask
+	for _, line := range strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		var task importedTask
+		if rest, ok := strings.CutPrefix(line, "x "); ok {
+			task.Done = true
+			line = strings.TrimSpace(rest)
+			task.CompletedAt, line = cutTodoDate(line)
+		} else if match := todoPriority.FindStringSubmatch(line); match != nil {
+			task.Priority = match[1]
+			line = line[len(match[0]):]
+		}
+		task.CreatedAt, line = cutTodoDate(line)
+
+		var words []string
+		for _, word := range strings.Fields(line) {
+			if level, ok := strings.CutPrefix(word, "pri:"); ok && task.Done && len(level) == 1 {
+				task.Priority = strings.ToUpper(level)
+				continue
+			}
+			if value, ok := strings.CutPrefix(word, "due:"); ok {
+				if due, err := time.ParseInLocation("2006-01-02", value, time.Local); err == nil {
+					task.Due = &due
+					continue
+				}
+			}
+			words = append(words, word)
+		}
+		words, task.Tags = splitTodoTags(words)
+		task.Title = strings.Join(words, " ")
+		if task.Title != "" {
+			tasks = append(tasks, task)
+		}
+	}
+	return tasks
+}
+
+// splitTodoTags turns todo.txt +project words, along with #tags, into
+// tags and @context words into @ tags.
+func splitTodoTags(words []string) ([]string, []string) {
+	marked := make([]string, len(words))
+	for i, word := range words {
+		marked[i] = word
+		if project, ok := strings.CutPrefix(word, "+"); ok {
+			if _, ok := normalizeTag(project); ok {
+				marked[i] = "#" + project
+			}
+		}
+	}
+	return splitTags(marked)
+}
+
+func cutTodoDate(text string) (time.Time, string) {
+	if len(text) < 10 || (len(text) > 10 && text[10] != ' ') {
+		return time.Time{}, text
+	}
+	date, err := time.ParseInLocation("2006-01-02", text[:10], time.Local)
+	if err != nil {
+		return time.Time{}, text
+	}
+	return date, strings.TrimSpace(text[10:])
+}
+
+// importTasks creates a new list the way create-list does and fills it
+// with the imported tasks.
+func importTasks(config *Config, listName string, tasks []importedTask) (string, error) {
+	if err := createNewList(config.TaskDir, listName); err != nil {
+		return "", err
+	}
+
+	taskFile := filepath.Join(config.TaskDir, sanitizeListName(listName)+".json")
+	taskList, err := loadTasks(taskFile)
+	if err != nil {
+		return "", newError(errIO, "load error: %v", err)
+	}
+
+	previous := notices
+	notices = io.Discard
+	defer func() { notices = previous }()
+
+	err = updateTasks(taskFile, taskList, func(taskList *TaskList) error {
+		var ids []int64
+		for _, imported := range tasks {
+			task := addTask(taskList, taskInput{Title: imported.Title, Priority: imported.Priority, Due: imported.Due, Tags: imported.Tags})
+			if n := len(taskList.Items); n > 1 && task.ID <= taskList.Items[n-2].ID {
+				task.ID = taskList.Items[n-2].ID + 1
+			}
+
+			if imported.Parent > 0 {
+				task.ParentID = ids[imported.Parent-1]
+			}
+			ids = append(ids, task.ID)
+
+			task.Comment = imported.Comment
+			if !imported.CreatedAt.IsZero() {
+				task.CreatedAt = imported.CreatedAt
+			}
+			if imported.Done {
+				completed := time.Now()
+				if !imported.CompletedAt.IsZero() {
+					completed = imported.CompletedAt
+				}
+				task.Status = StatusDone
+				task.CompletedAt = &completed
+			}
+		}
+		return nil
+	})
+	return taskFile, err
+}
+
+func handleImport(config *Config) error {
+	listName, args := extractFlag(os.Args[2:], "--list")
+	if len(args) < 2 {
+		return newError(errInvalidArgument, "usage: tgo import md|todotxt <file> [--list <name>]")
+	}
+	if config.TaskDir == "" {
+		return errNoTaskDir
+	}
+
+	format, sourceFile := args[0], args[1]
+	data, err := os.ReadFile(sourceFile)
+	if err != nil {
+		return newError(errIO, "cannot read %s: %v", sourceFile, err)
+	}
+
+	var title string
+	var tasks []importedTask
+	switch format {
+	case "md", "markdown":
+		title, tasks = parseMarkdown(string(data))
+	case "todotxt", "todo.txt":
+		tasks = parseTodoTxt(string(data))
+	default:
+		return newError(errInvalidArgument, "unsupported import format '%s', use md or todotxt", format)
+	}
+
+	if listName == "" {
+		listName = title
+	}
+	if listName == "" {
+		listName = strings.TrimSuffix(filepath.Base(sourceFile), filepath.Ext(sourceFile))
+	}
+
+	taskFile, err := importTasks(config, listName, tasks)
+	if err != nil {
+		return err
+	}
+
+	if jsonOutput {
+		printJSON(commandResult{OK: true, Command: "import", List: listName, Path: taskFile})
+		return nil
+	}
+	fmt.Printf("📥 Imported %d task(s) into %s\n", len(tasks), listName)
+	return nil
+}
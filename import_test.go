@@ -0,0 +1,85 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseTodoTxt(t *testing.T) {
+	day := func(value string) time.Time {
+		date, err := time.ParseInLocation("2006-01-02", value, time.Local)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return date
+	}
+	due := day("2026-11-01")
+
+	tests := []struct {
+		name string
+		line string
+		want importedTask
+	}{
+		{"plain", "Call mom", importedTask{Title: "Call mom"}},
+		{"priority and created", "(A) 2026-10-01 Write report",
+			importedTask{Title: "Write report", Priority: "A", CreatedAt: day("2026-10-01")}},
+		{"completed", "x 2026-10-05 2026-10-01 Ship it pri:B",
+			importedTask{Title: "Ship it", Priority: "B", Done: true, CompletedAt: day("2026-10-05"), CreatedAt: day("2026-10-01")}},
+		{"pri on open task stays", "Ask pri:c", importedTask{Title: "Ask pri:c"}},
+		{"project and context", "Buy milk +Groceries @store #errand",
+			importedTask{Title: "Buy milk", Tags: []string{"groceries", "@store", "errand"}}},
+		{"context apart from project", "Call mom @phone +phone",
+			importedTask{Title: "Call mom", Tags: []string{"@phone", "phone"}}},
+		{"numeric context stays", "Meet @10", importedTask{Title: "Meet @10"}},
+		{"numeric project stays", "Fix +1 issue", importedTask{Title: "Fix +1 issue"}},
+		{"due", "Pay rent due:2026-11-01", importedTask{Title: "Pay rent", Due: &due}},
+		{"bad due stays", "Pay rent due:soon", importedTask{Title: "Pay rent due:soon"}},
+		{"other extension stays", "Read book url:example.com", importedTask{Title: "Read book url:example.com"}},
+		{"lowercase x is a word", "xylophone lesson", importedTask{Title: "xylophone lesson"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := parseTodoTxt(test.line)
+			if len(got) != 1 {
+				t.Fatalf("parseTodoTxt(%q) returned %d tasks", test.line, len(got))
+			}
+			if !reflect.DeepEqual(got[0], test.want) {
+				t.Errorf("parseTodoTxt(%q) = %+v, want %+v", test.line, got[0], test.want)
+			}
+		})
+	}
+}
+
+func TestParseTodoTxtSkipsBlankLines(t *testing.T) {
+	got := parseTodoTxt("first\r\n\n  \nsecond\n")
+	if len(got) != 2 || got[0].Title != "first" || got[1].Title != "second" {
+		t.Errorf("parseTodoTxt = %+v, want first and second", got)
+	}
+}
+
+func TestTodoTxtRoundTrip(t *testing.T) {
+	lines := []string{
+		"Call mom @phone",
+		"(B) Plan trip +travel @home due:2026-11-01",
+		"x 2026-10-05 Ship it pri:A +release",
+	}
+	for _, line := range lines {
+		imported := parseTodoTxt(line)
+		if len(imported) != 1 {
+			t.Fatalf("parseTodoTxt(%q) returned %d tasks", line, len(imported))
+		}
+		task := Task{Title: imported[0].Title, Priority: imported[0].Priority, Due: imported[0].Due, Tags: imported[0].Tags}
+		if imported[0].Done {
+			task.Status = StatusDone
+			task.CompletedAt = &imported[0].CompletedAt
+		}
+
+		got := strings.TrimSpace(string(todoTxtLines(&TaskList{Items: []Task{task}})))
+		if got != line {
+			t.Errorf("round trip of %q = %q", line, got)
+		}
+	}
+}
@@ -0,0 +1,191 @@
+package main
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"unicode/utf8"
+)
+
+// lineReader reads stdin on a background goroutine, but only when a line
+// has been requested, so child processes such as $EDITOR never compete
+// with a pending read for terminal input.
+type lineReader struct {
+	requests chan struct{}
+	lines    chan string
+	done     chan struct{}
+	pending  bool
+}
+
+var stdin = newLineReader(os.Stdin)
+
+func newLineReader(r io.Reader) *lineReader {
+	reader := &lineReader{
+		requests: make(chan struct{}),
+		lines:    make(chan string),
+		done:     make(chan struct{}),
+	}
+
+	go func() {
+		scanner := bufio.NewScanner(r)
+		for range reader.requests {
+			if !scanner.Scan() {
+				close(reader.lines)
+				close(reader.done)
+				return
+			}
+			reader.lines <- scanner.Text()
+		}
+	}()
+
+	return reader
+}
+
+// wait requests a line unless one is already pending. After EOF it
+// returns the closed lines channel, so callers see ok == false instead of
+// blocking on a reader that has exited.
+func (r *lineReader) wait() <-chan string {
+	if !r.pending {
+		select {
+		case r.requests <- struct{}{}:
+			r.pending = true
+		case <-r.done:
+		}
+	}
+	return r.lines
+}
+
+func (r *lineReader) consumed() {
+	r.pending = false
+}
+
+func readLine() (string, bool) {
+	line, ok := <-stdin.wait()
+	stdin.consumed()
+	return line, ok
+}
+
+// keyReader is the raw-mode counterpart of lineReader: each request reads
+// one chunk of bytes, which holds one or more key presses.
+type keyReader struct {
+	requests chan struct{}
+	chunks   chan []byte
+	pending  bool
+}
+
+func newKeyReader(f *os.File) *keyReader {
+	reader := &keyReader{
+		requests: make(chan struct{}),
+		chunks:   make(chan []byte),
+	}
+
+	go func() {
+		for range reader.requests {
+			buf := make([]byte, 256)
+			n, err := f.Read(buf)
+			if err != nil {
+				close(reader.chunks)
+				return
+			}
+			reader.chunks <- buf[:n]
+		}
+	}()
+
+	return reader
+}
+
+func (r *keyReader) wait() <-chan []byte {
+	if !r.pending {
+		r.pending = true
+		r.requests <- struct{}{}
+	}
+	return r.chunks
+}
+
+func (r *keyReader) consumed() {
+	r.pending = false
+}
+
+func (r *keyReader) stop() {
+	close(r.requests)
+}
+
+type keyCode int
+
+const (
+	keyRune keyCode = iota
+	keyUp
+	keyDown
+	keyLeft
+	keyRight
+	keyHome
+	keyEnd
+	keyEnter
+	keyTab
+	keyBackspace
+	keyDelete
+	keyEsc
+	keyCtrlC
+)
+
+type key struct {
+	code keyCode
+	r    rune
+}
+
+func parseKeys(b []byte) []key {
+	var keys []key
+	for len(b) > 0 {
+		switch b[0] {
+		case 0x1b:
+			if len(b) < 3 || (b[1] != '[' && b[1] != 'O') {
+				keys = append(keys, key{code: keyEsc})
+				b = b[1:]
+				continue
+			}
+			end := 2
+			for end < len(b) && (b[end] < 0x40 || b[end] > 0x7e) {
+				end++
+			}
+			if end == len(b) {
+				return keys
+			}
+			switch string(b[2 : end+1]) {
+			case "A":
+				keys = append(keys, key{code: keyUp})
+			case "B":
+				keys = append(keys, key{code: keyDown})
+			case "C":
+				keys = append(keys, key{code: keyRight})
+			case "D":
+				keys = append(keys, key{code: keyLeft})
+			case "H", "1~":
+				keys = append(keys, key{code: keyHome})
+			case "F", "4~":
+				keys = append(keys, key{code: keyEnd})
+			case "3~":
+				keys = append(keys, key{code: keyDelete})
+			}
+			b = b[end+1:]
+		case 0x03:
+			keys = append(keys, key{code: keyCtrlC})
+			b = b[1:]
+		case '\r', '\n':
+			keys = append(keys, key{code: keyEnter})
+			b = b[1:]
+		case '\t':
+			keys = append(keys, key{code: keyTab})
+			b = b[1:]
+		case 0x7f, 0x08:
+			keys = append(keys, key{code: keyBackspace})
+			b = b[1:]
+		default:
+			r, size := utf8.DecodeRune(b)
+			if r >= 0x20 {
+				keys = append(keys, key{code: keyRune, r: r})
+			}
+			b = b[size:]
+		}
+	}
+	return keys
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+type liveView struct {
+	taskList *TaskList
+	fileName string
+	opts     *DisplayOptions
+	lines    int
+}
+
+func (v *liveView) render() string {
+	var buf bytes.Buffer
+	renderTaskList(&buf, v.taskList, v.fileName, *v.opts)
+	return buf.String()
+}
+
+func (v *liveView) draw() {
+	view := v.render()
+	clearScreen()
+	fmt.Print(view)
+	fmt.Print("\n> ")
+	v.lines = strings.Count(view, "\n")
+}
+
+// refresh rewrites the task list above the prompt in place, leaving the
+// cursor and any partially typed input untouched. It falls back to a full
+// redraw when the number of lines changed.
+func (v *liveView) refresh() {
+	view := v.render()
+	if strings.Count(view, "\n") != v.lines {
+		v.draw()
+		return
+	}
+
+	var b strings.Builder
+	b.WriteString("\0337\033[H")
+	for _, line := range strings.SplitAfter(view, "\n") {
+		if line == "" {
+			continue
+		}
+		b.WriteString(strings.TrimSuffix(line, "\n"))
+		b.WriteString("\033[K\n")
+	}
+	b.WriteString("\0338")
+	fmt.Print(b.String())
+}
+
+func hasActiveTask(taskList *TaskList) bool {
+	for _, task := range taskList.Items {
+		if task.IsActive() {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,7 @@
+//go:build !unix
+
+package main
+
+func lockFile(filePath string) (func(), error) {
+	return func() {}, nil
+}
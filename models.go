@@ -1,19 +1,35 @@
 package main
 
 import (
+	"crypto/sha1"
+	"crypto/sha256"
+	"fmt"
+	"strconv"
 	"time"
 )
 
 type Task struct {
-	ID               int64      `json:"id"`
-	Title            string     `json:"title"`
-	Status           TaskStatus `json:"status"`
-	Comment          string     `json:"comment"`
-	Sessions         []Session  `json:"sessions"`
-	TotalDuration    int64      `json:"total_duration"`
-	ActiveStartTime  *time.Time `json:"active_start_time,omitempty"`
-	CompletedAt      *time.Time `json:"completed_at,omitempty"`
-	CreatedAt        time.Time  `json:"created_at"`
+	ID              int64        `json:"id"`
+	Title           string       `json:"title"`
+	Status          TaskStatus   `json:"status"`
+	Priority        string       `json:"priority,omitempty"`
+	Due             *time.Time   `json:"due,omitempty"`
+	Tags            []string     `json:"tags,omitempty"`
+	ParentID        int64        `json:"parent_id,omitempty"`
+	BlockedBy       []int64      `json:"blocked_by,omitempty"`
+	Comment         string       `json:"comment"`
+	Sessions        []Session    `json:"sessions"`
+	TotalDuration   int64        `json:"total_duration"`
+	ActiveStartTime *time.Time   `json:"active_start_time,omitempty"`
+	CompletedAt     *time.Time   `json:"completed_at,omitempty"`
+	CreatedAt       time.Time    `json:"created_at"`
+	History         []AuditEntry `json:"history,omitempty"`
+}
+
+type AuditEntry struct {
+	Action string    `json:"action"`
+	At     time.Time `json:"at"`
+	Detail string    `json:"detail,omitempty"`
 }
 
 type Session struct {
@@ -32,14 +48,77 @@ const (
 )
 
 type TaskList struct {
-	Title     string `json:"title"`
-	Items     []Task `json:"items"`
+	Title     string    `json:"title"`
+	Items     []Task    `json:"items"`
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
+
+	checksum [sha256.Size]byte
+}
+
+type DisplayOptions struct {
+	HideNotes    bool
+	ShowCommands bool
+	Statuses     []TaskStatus
+	Tag          string
+}
+
+// State is shared by every tgo process using the same task folder.
+type State struct {
+	Running *RunningTimer `json:"running"`
+}
+
+type RunningTimer struct {
+	List      string    `json:"list"`
+	TaskID    int64     `json:"task_id"`
+	StartedAt time.Time `json:"started_at"`
 }
 
 type Config struct {
-	TaskDir string `json:"task_folder"`
+	TaskDir     string `json:"task_folder"`
+	DefaultList string `json:"default_list,omitempty"`
+	MaxSession  string `json:"max_session,omitempty"`
+	IdlePolicy  string `json:"idle_policy,omitempty"`
+}
+
+func (o DisplayOptions) filter(statuses ...TaskStatus) []TaskStatus {
+	if len(o.Statuses) == 0 {
+		return statuses
+	}
+
+	var shown []TaskStatus
+	for _, status := range statuses {
+		for _, wanted := range o.Statuses {
+			if status == wanted {
+				shown = append(shown, status)
+			}
+		}
+	}
+	return shown
+}
+
+// tasks returns the tasks with one of the statuses that carry the tag
+// filter, or have a subtask that does, in display order.
+func (o DisplayOptions) tasks(taskList *TaskList, statuses ...TaskStatus) []int {
+	indexes := tasksWithStatus(taskList, statuses...)
+	if o.Tag == "" {
+		return indexes
+	}
+
+	tagged := indexes[:0]
+	for _, i := range indexes {
+		if hasTag(&taskList.Items[i], o.Tag) {
+			tagged = append(tagged, i)
+			continue
+		}
+		for _, child := range descendants(taskList, taskList.Items[i].ID) {
+			if hasTag(&taskList.Items[child], o.Tag) {
+				tagged = append(tagged, i)
+				break
+			}
+		}
+	}
+	return tagged
 }
 
 func (t *Task) IsActive() bool {
@@ -50,6 +129,11 @@ func (t *Task) IsDone() bool {
 	return t.Status == StatusDone
 }
 
+func (t *Task) Hash() string {
+	sum := sha1.Sum([]byte(strconv.FormatInt(t.ID, 10)))
+	return fmt.Sprintf("%x", sum)
+}
+
 func (t *Task) GetFormattedDuration() string {
 	return formatDuration(t.TotalDuration)
 }
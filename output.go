@@ -0,0 +1,130 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+var jsonOutput bool
+
+type errorResult struct {
+	OK    bool        `json:"ok"`
+	Error errorDetail `json:"error"`
+}
+
+type errorDetail struct {
+	Code    errorKind `json:"code"`
+	Message string    `json:"message"`
+}
+
+type taskResult struct {
+	OK             bool       `json:"ok"`
+	Command        string     `json:"command"`
+	List           string     `json:"list"`
+	ShortID        string     `json:"short_id"`
+	Status         TaskStatus `json:"status"`
+	TotalSeconds   int64      `json:"total_seconds"`
+	RunningSeconds int64      `json:"running_seconds,omitempty"`
+	Task           Task       `json:"task"`
+}
+
+type commandResult struct {
+	OK      bool   `json:"ok"`
+	Command string `json:"command"`
+	List    string `json:"list,omitempty"`
+	Path    string `json:"path,omitempty"`
+}
+
+type listsResult struct {
+	OK      bool          `json:"ok"`
+	Command string        `json:"command"`
+	Lists   []listSummary `json:"lists"`
+}
+
+type showResult struct {
+	OK      bool        `json:"ok"`
+	Command string      `json:"command"`
+	List    listSummary `json:"list"`
+}
+
+type listSummary struct {
+	File         string `json:"file"`
+	Title        string `json:"title"`
+	Active       int    `json:"active"`
+	Pending      int    `json:"pending"`
+	Done         int    `json:"done"`
+	TotalSeconds int64  `json:"total_seconds"`
+	Tasks        []Task `json:"tasks"`
+}
+
+func summarizeList(fileName string, taskList *TaskList, tasks []Task) listSummary {
+	activeCount, pendingCount, doneCount := countTasks(taskList)
+	if tasks == nil {
+		tasks = []Task{}
+	}
+	return listSummary{
+		File:         fileName,
+		Title:        taskList.Title,
+		Active:       activeCount,
+		Pending:      pendingCount,
+		Done:         doneCount,
+		TotalSeconds: int64(time.Duration(trackedDuration(taskList)).Seconds()),
+		Tasks:        tasks,
+	}
+}
+
+func enableJSONOutput() {
+	jsonOutput = true
+	notices = io.Discard
+}
+
+func printJSON(v any) {
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	encoder.Encode(v)
+}
+
+func reportError(err error) {
+	var ce *cliError
+	if errors.As(err, &ce) && ce.reported {
+		return
+	}
+	if jsonOutput {
+		printJSON(errorResult{Error: errorDetail{Code: kindOf(err), Message: err.Error()}})
+		return
+	}
+	fmt.Printf("❌ %v\n", err)
+}
+
+func printTaskResult(command string, taskFile string, taskList *TaskList, id int64) error {
+	if !jsonOutput {
+		return nil
+	}
+
+	taskNum, err := findTaskByID(taskList, id)
+	if err != nil {
+		return err
+	}
+
+	task := taskList.Items[taskNum-1]
+	result := taskResult{
+		OK:           true,
+		Command:      command,
+		List:         strings.TrimSuffix(filepath.Base(taskFile), ".json"),
+		ShortID:      shortID(taskList, &task),
+		Status:       task.Status,
+		TotalSeconds: int64(time.Duration(task.TotalDuration).Seconds()),
+		Task:         task,
+	}
+	if task.ActiveStartTime != nil {
+		result.RunningSeconds = int64(time.Since(*task.ActiveStartTime).Seconds())
+	}
+	printJSON(result)
+	return nil
+}
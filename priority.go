@@ -0,0 +1,81 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// parsePriority accepts A–Z (as in todo.txt) or P0–P3 as shorthand for
+// A–D. An empty level, "-" or "none" clears the priority.
+func parsePriority(level string) (string, error) {
+	level = strings.ToUpper(strings.TrimSpace(level))
+	switch {
+	case level == "" || level == "-" || level == "NONE":
+		return "", nil
+	case len(level) == 1 && level[0] >= 'A' && level[0] <= 'Z':
+		return level, nil
+	case len(level) == 2 && level[0] == 'P' && level[1] >= '0' && level[1] <= '3':
+		return string(rune('A' + level[1] - '0')), nil
+	}
+	return "", newError(errInvalidArgument, "invalid priority '%s', use A-Z or P0-P3", level)
+}
+
+// priorityRank orders tasks by priority, with unprioritized tasks last.
+func priorityRank(priority string) int {
+	if priority == "" {
+		return 'Z' + 1
+	}
+	return int(priority[0])
+}
+
+func setTaskPriority(taskList *TaskList, index int, priority string) error {
+	if index < 1 || index > len(taskList.Items) {
+		return newError(errNotFound, "invalid task number. Use 1-%d", len(taskList.Items))
+	}
+
+	task := &taskList.Items[index-1]
+	task.Priority = priority
+	if priority == "" {
+		fmt.Fprintf(notices, "🔻 Cleared priority: %s\n", task.Title)
+	} else {
+		fmt.Fprintf(notices, "🔺 Priority %s: %s\n", priority, task.Title)
+	}
+	return nil
+}
+
+func handlePriority(config *Config) error {
+	listName, args := extractFlag(os.Args[2:], "--list")
+	if len(args) < 1 {
+		return newError(errInvalidArgument, "usage: tgo prio <number|id> <A-Z|P0-P3|none>")
+	}
+
+	level := ""
+	if len(args) > 1 {
+		level = args[1]
+	}
+	priority, err := parsePriority(level)
+	if err != nil {
+		return err
+	}
+
+	return runTaskCommand(config, "prio", listName, args[0], func(taskList *TaskList, taskNum int) error {
+		return setTaskPriority(taskList, taskNum, priority)
+	})
+}
+
+func handlePriorityTask(arg string, taskList *TaskList, taskFile string) {
+	taskRef, level, _ := strings.Cut(arg, " ")
+	priority, err := parsePriority(level)
+	if err != nil {
+		fmt.Printf("❌ %v\n", err)
+		return
+	}
+
+	err = updateTask(taskFile, taskList, taskRef, func(taskList *TaskList, taskNum int) error {
+		return setTaskPriority(taskList, taskNum, priority)
+	})
+	if err != nil {
+		fmt.Printf("❌ %v\n", err)
+	}
+}
@@ -0,0 +1,251 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// sessionEntry is one recorded or running session, together with the
+// list and task it belongs to.
+type sessionEntry struct {
+	File    string
+	List    string
+	Task    Task
+	Start   time.Time
+	End     time.Time
+	Running bool
+}
+
+type reportRow struct {
+	Group   string `json:"group"`
+	Seconds int64  `json:"seconds"`
+
+	key      string
+	duration time.Duration
+}
+
+type reportResult struct {
+	OK           bool        `json:"ok"`
+	Command      string      `json:"command"`
+	From         time.Time   `json:"from"`
+	To           time.Time   `json:"to"`
+	GroupBy      string      `json:"group_by"`
+	Rows         []reportRow `json:"rows"`
+	TotalSeconds int64       `json:"total_seconds"`
+}
+
+func startOfDay(t time.Time) time.Time {
+	t = t.Local()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.Local)
+}
+
+// parseRange reads --from/--to values, defaulting to the last seven days.
+// A bare date in --to includes that whole day.
+func parseRange(fromSpec, toSpec string, now time.Time) (time.Time, time.Time, error) {
+	today := startOfDay(now)
+	from, to := today.AddDate(0, 0, -6), today.AddDate(0, 0, 1)
+
+	var err error
+	if fromSpec != "" {
+		if from, err = parseTimeSpec(fromSpec, now); err != nil {
+			return from, to, err
+		}
+	}
+	if toSpec != "" {
+		if to, err = parseTimeSpec(toSpec, now); err != nil {
+			return from, to, err
+		}
+		if _, ok := parseDateWord(strings.ToLower(strings.TrimSpace(toSpec)), today); ok {
+			to = to.AddDate(0, 0, 1)
+		}
+	}
+	if !to.After(from) {
+		return from, to, newError(errInvalidArgument, "--to must be after --from")
+	}
+	return from, to, nil
+}
+
+// collectSessions returns the sessions of every list, or only the named
+// one, that overlap [from, to). Running timers count up to now.
+func collectSessions(config *Config, listName string, from, to, now time.Time) ([]sessionEntry, error) {
+	if config.TaskDir == "" {
+		return nil, errNoTaskDir
+	}
+
+	var taskFiles []string
+	if listName != "" {
+		taskFile, err := findListFile(config.TaskDir, listName)
+		if err != nil {
+			return nil, err
+		}
+		taskFiles = append(taskFiles, filepath.Base(taskFile))
+	} else {
+		files, err := findTaskFiles(config.TaskDir)
+		if err != nil {
+			return nil, err
+		}
+		taskFiles = files
+	}
+
+	var entries []sessionEntry
+	for _, file := range taskFiles {
+		taskList, err := loadTasks(filepath.Join(config.TaskDir, file))
+		if err != nil {
+			return nil, newError(errIO, "cannot load %s: %v", file, err)
+		}
+
+		for _, task := range taskList.Items {
+			add := func(start, end time.Time, running bool) {
+				if start.Before(to) && end.After(from) {
+					entries = append(entries, sessionEntry{
+						File: file, List: taskList.Title, Task: task,
+						Start: start, End: end, Running: running,
+					})
+				}
+			}
+			for _, session := range task.Sessions {
+				add(session.StartTime, session.EndTime, false)
+			}
+			if task.IsActive() && task.ActiveStartTime != nil {
+				add(*task.ActiveStartTime, now, true)
+			}
+		}
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].Start.Before(entries[j].Start)
+	})
+	return entries, nil
+}
+
+func reportGroup(groupBy string, entry sessionEntry, day time.Time) (string, string) {
+	switch groupBy {
+	case "week":
+		year, week := day.ISOWeek()
+		monday := day.AddDate(0, 0, -(int(day.Weekday())+6)%7)
+		return fmt.Sprintf("%d-W%02d", year, week), fmt.Sprintf("%d-W%02d (from %s)", year, week, monday.Format("Jan 2"))
+	case "list":
+		return entry.File, entry.List
+	case "task":
+		return fmt.Sprint(entry.Task.ID), fmt.Sprintf("%s (%s)", entry.Task.Title, entry.List)
+	}
+	return day.Format("2006-01-02"), day.Format("2006-01-02 Mon")
+}
+
+// groupSessions adds up the part of each session inside [from, to) by
+// group, splitting sessions at local midnight so that every day only gets
+// its own share.
+func groupSessions(entries []sessionEntry, groupBy string, from, to time.Time) ([]reportRow, time.Duration) {
+	var rows []reportRow
+	index := map[string]int{}
+	var total time.Duration
+	for _, entry := range entries {
+		start, end := entry.Start, entry.End
+		if start.Before(from) {
+			start = from
+		}
+		if end.After(to) {
+			end = to
+		}
+
+		for start.Before(end) {
+			day := startOfDay(start)
+			next := day.AddDate(0, 0, 1)
+			if next.After(end) {
+				next = end
+			}
+
+			key, label := reportGroup(groupBy, entry, day)
+			if _, ok := index[key]; !ok {
+				index[key] = len(rows)
+				rows = append(rows, reportRow{Group: label, key: key})
+			}
+			rows[index[key]].duration += next.Sub(start)
+			total += next.Sub(start)
+			start = next
+		}
+	}
+
+	for i := range rows {
+		rows[i].Seconds = int64(rows[i].duration.Seconds())
+	}
+	sort.SliceStable(rows, func(i, j int) bool {
+		if groupBy == "day" || groupBy == "week" {
+			return rows[i].key < rows[j].key
+		}
+		return rows[i].duration > rows[j].duration
+	})
+	return rows, total
+}
+
+func handleReport(config *Config) error {
+	fromSpec, args := extractFlag(os.Args[2:], "--from")
+	toSpec, args := extractFlag(args, "--to")
+	groupBy, args := extractFlag(args, "--group-by")
+	listName, _ := extractFlag(args, "--list")
+
+	switch groupBy {
+	case "":
+		groupBy = "day"
+	case "day", "week", "list", "task":
+	default:
+		return newError(errInvalidArgument, "invalid --group-by '%s', use day, week, list or task", groupBy)
+	}
+
+	now := time.Now()
+	from, to, err := parseRange(fromSpec, toSpec, now)
+	if err != nil {
+		return err
+	}
+	entries, err := collectSessions(config, listName, from, to, now)
+	if err != nil {
+		return err
+	}
+
+	rows, total := groupSessions(entries, groupBy, from, to)
+	running := false
+	for _, entry := range entries {
+		running = running || entry.Running
+	}
+
+	if jsonOutput {
+		if rows == nil {
+			rows = []reportRow{}
+		}
+		printJSON(reportResult{OK: true, Command: "report", From: from, To: to, GroupBy: groupBy,
+			Rows: rows, TotalSeconds: int64(total.Seconds())})
+		return nil
+	}
+
+	fmt.Printf("📊 Time report %s – %s (by %s)\n\n",
+		from.Local().Format("2006-01-02"), to.Add(-time.Nanosecond).Local().Format("2006-01-02"), groupBy)
+	if len(rows) == 0 {
+		fmt.Println("  No time tracked in this range")
+		return nil
+	}
+
+	width := len("Total")
+	for _, row := range rows {
+		w := 0
+		for _, r := range row.Group {
+			w += runeWidth(r)
+		}
+		width = max(width, min(w, 48))
+	}
+
+	for _, row := range rows {
+		fmt.Printf("  %s  %12s  %6.2fh\n", padRight(row.Group, width),
+			formatDuration(row.duration.Truncate(time.Second).Nanoseconds()), row.duration.Hours())
+	}
+	fmt.Printf("  %s\n", strings.Repeat("─", width+24))
+	fmt.Printf("  %s  %12s  %6.2fh\n", padRight("Total", width),
+		formatDuration(total.Truncate(time.Second).Nanoseconds()), total.Hours())
+	if running {
+		fmt.Println("\n  🟢 Includes a running timer up to now")
+	}
+	return nil
+}
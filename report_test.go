@@ -0,0 +1,71 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestGroupSessionsSplitsDays(t *testing.T) {
+	at := func(day, hour int) time.Time {
+		return time.Date(2026, time.October, day, hour, 0, 0, 0, time.Local)
+	}
+	entry := func(start, end time.Time) sessionEntry {
+		return sessionEntry{File: "work.json", List: "work", Task: Task{ID: 1, Title: "Review"}, Start: start, End: end}
+	}
+	from, to := at(10, 0), at(17, 0)
+
+	tests := []struct {
+		name    string
+		entries []sessionEntry
+		want    map[string]time.Duration
+	}{
+		{"within a day", []sessionEntry{entry(at(12, 9), at(12, 11))},
+			map[string]time.Duration{"2026-10-12": 2 * time.Hour}},
+		{"across midnight", []sessionEntry{entry(at(12, 22), at(13, 1))},
+			map[string]time.Duration{"2026-10-12": 2 * time.Hour, "2026-10-13": time.Hour}},
+		{"over several days", []sessionEntry{entry(at(12, 12), at(14, 6))},
+			map[string]time.Duration{"2026-10-12": 12 * time.Hour, "2026-10-13": 24 * time.Hour, "2026-10-14": 6 * time.Hour}},
+		{"ending at midnight", []sessionEntry{entry(at(12, 20), at(13, 0))},
+			map[string]time.Duration{"2026-10-12": 4 * time.Hour}},
+		{"clipped to the range", []sessionEntry{entry(at(9, 20), at(10, 3)), entry(at(16, 23), at(17, 2))},
+			map[string]time.Duration{"2026-10-10": 3 * time.Hour, "2026-10-16": time.Hour}},
+		{"same day adds up", []sessionEntry{entry(at(12, 9), at(12, 10)), entry(at(12, 14), at(12, 16))},
+			map[string]time.Duration{"2026-10-12": 3 * time.Hour}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			rows, total := groupSessions(test.entries, "day", from, to)
+			got := map[string]time.Duration{}
+			var sum time.Duration
+			for _, row := range rows {
+				got[row.key] = row.duration
+				sum += row.duration
+				if row.Seconds != int64(row.duration.Seconds()) {
+					t.Errorf("%s: Seconds = %d, want %d", row.key, row.Seconds, int64(row.duration.Seconds()))
+				}
+			}
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("groupSessions = %v, want %v", got, test.want)
+			}
+			if total != sum {
+				t.Errorf("total = %v, want %v", total, sum)
+			}
+		})
+	}
+}
+
+func TestGroupSessionsSortsDaysInOrder(t *testing.T) {
+	at := func(day, hour int) time.Time {
+		return time.Date(2026, time.October, day, hour, 0, 0, 0, time.Local)
+	}
+	entries := []sessionEntry{
+		{Task: Task{ID: 1}, Start: at(14, 9), End: at(14, 10)},
+		{Task: Task{ID: 2}, Start: at(12, 9), End: at(12, 10)},
+	}
+	rows, _ := groupSessions(entries, "day", at(10, 0), at(17, 0))
+	if len(rows) != 2 || rows[0].key != "2026-10-12" || rows[1].key != "2026-10-14" {
+		t.Errorf("groupSessions rows = %+v, want 2026-10-12 then 2026-10-14", rows)
+	}
+}
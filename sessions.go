@@ -0,0 +1,289 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+func recomputeTotal(task *Task) {
+	var total int64
+	for i := range task.Sessions {
+		session := &task.Sessions[i]
+		session.Duration = session.EndTime.Sub(session.StartTime).Nanoseconds()
+		total += session.Duration
+	}
+	task.TotalDuration = total
+}
+
+func logSession(taskList *TaskList, index int, start, end time.Time) error {
+	if index < 1 || index > len(taskList.Items) {
+		return newError(errNotFound, "invalid task number. Use 1-%d", len(taskList.Items))
+	}
+	if !end.After(start) {
+		return newError(errInvalidArgument, "session must end after it starts")
+	}
+
+	task := &taskList.Items[index-1]
+	if err := checkOverlap(task, start, end, -1); err != nil {
+		return err
+	}
+	task.Sessions = append(task.Sessions, Session{StartTime: start, EndTime: end})
+	sort.SliceStable(task.Sessions, func(i, j int) bool {
+		return task.Sessions[i].StartTime.Before(task.Sessions[j].StartTime)
+	})
+	recomputeTotal(task)
+	if task.Status == StatusPending {
+		task.Status = StatusPaused
+	}
+
+	fmt.Fprintf(notices, "⏱️ Logged %s on %s [Total: %s]\n",
+		formatDuration(end.Sub(start).Nanoseconds()), task.Title, task.GetFormattedDuration())
+	return nil
+}
+
+// checkOverlap rejects a span that would overlap one of the task's
+// sessions, other than the one at skip, or its running timer.
+func checkOverlap(task *Task, start, end time.Time, skip int) error {
+	for i, session := range task.Sessions {
+		if i != skip && start.Before(session.EndTime) && session.StartTime.Before(end) {
+			return newError(errConflict, "overlaps session %d (%s → %s)", i+1,
+				session.StartTime.Local().Format("2006-01-02 15:04"), session.EndTime.Local().Format("15:04"))
+		}
+	}
+	if task.ActiveStartTime != nil && end.After(*task.ActiveStartTime) {
+		return newError(errConflict, "overlaps the running timer started at %s",
+			task.ActiveStartTime.Local().Format("2006-01-02 15:04"))
+	}
+	return nil
+}
+
+func editSession(taskList *TaskList, index int, sessionNum int, start, end *time.Time, duration time.Duration) error {
+	if index < 1 || index > len(taskList.Items) {
+		return newError(errNotFound, "invalid task number. Use 1-%d", len(taskList.Items))
+	}
+
+	task := &taskList.Items[index-1]
+	if sessionNum < 1 || sessionNum > len(task.Sessions) {
+		return newError(errNotFound, "invalid session number. Use 1-%d", len(task.Sessions))
+	}
+
+	session := task.Sessions[sessionNum-1]
+	switch {
+	case start != nil && end != nil:
+		session.StartTime, session.EndTime = *start, *end
+	case start != nil && duration > 0:
+		session.StartTime, session.EndTime = *start, start.Add(duration)
+	case end != nil && duration > 0:
+		session.StartTime, session.EndTime = end.Add(-duration), *end
+	case start != nil:
+		session.StartTime = *start
+	case end != nil:
+		session.EndTime = *end
+	case duration > 0:
+		session.EndTime = session.StartTime.Add(duration)
+	default:
+		return newError(errInvalidArgument, "nothing to change, use --start, --end or --duration")
+	}
+	if !session.EndTime.After(session.StartTime) {
+		return newError(errInvalidArgument, "session must end after it starts")
+	}
+	if err := checkOverlap(task, session.StartTime, session.EndTime, sessionNum-1); err != nil {
+		return err
+	}
+
+	task.Sessions[sessionNum-1] = session
+	sort.SliceStable(task.Sessions, func(i, j int) bool {
+		return task.Sessions[i].StartTime.Before(task.Sessions[j].StartTime)
+	})
+	recomputeTotal(task)
+
+	fmt.Fprintf(notices, "✏️ Updated session on %s [Total: %s]\n", task.Title, task.GetFormattedDuration())
+	return nil
+}
+
+func removeSession(taskList *TaskList, index int, sessionNum int) error {
+	if index < 1 || index > len(taskList.Items) {
+		return newError(errNotFound, "invalid task number. Use 1-%d", len(taskList.Items))
+	}
+
+	task := &taskList.Items[index-1]
+	if sessionNum < 1 || sessionNum > len(task.Sessions) {
+		return newError(errNotFound, "invalid session number. Use 1-%d", len(task.Sessions))
+	}
+
+	removed := task.Sessions[sessionNum-1]
+	task.Sessions = append(task.Sessions[:sessionNum-1], task.Sessions[sessionNum:]...)
+	recomputeTotal(task)
+
+	fmt.Fprintf(notices, "🗑️ Removed session of %s from %s [Total: %s]\n",
+		formatDuration(removed.Duration), task.Title, task.GetFormattedDuration())
+	return nil
+}
+
+func displaySessions(task *Task) {
+	if len(task.Sessions) == 0 {
+		fmt.Printf("⏱️ No sessions recorded for %s\n", task.Title)
+		return
+	}
+
+	fmt.Printf("⏱️ Sessions for %s:\n\n", task.Title)
+	for i, session := range task.Sessions {
+		start := session.StartTime.Local()
+		end := session.EndTime.Local()
+		endFormat := "15:04"
+		if start.YearDay() != end.YearDay() || start.Year() != end.Year() {
+			endFormat = "2006-01-02 15:04"
+		}
+		fmt.Printf("  %d. %s → %s  %s\n", i+1, start.Format("2006-01-02 15:04"), end.Format(endFormat),
+			formatDuration(session.Duration))
+	}
+	fmt.Printf("\n  Total: %s\n", task.GetFormattedDuration())
+}
+
+func sessionBounds(duration time.Duration, at string, now time.Time) (time.Time, time.Time, error) {
+	if duration <= 0 {
+		return time.Time{}, time.Time{}, newError(errInvalidArgument, "duration must be positive")
+	}
+	if at == "" {
+		return now.Add(-duration), now, nil
+	}
+
+	start, err := parseTimeSpec(at, now)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	return start, start.Add(duration), nil
+}
+
+func parseDurationArg(value string) (time.Duration, error) {
+	duration, err := time.ParseDuration(strings.ReplaceAll(value, " ", ""))
+	if err != nil {
+		return 0, newError(errInvalidArgument, "invalid duration '%s', use e.g. 1h30m or 45m", value)
+	}
+	return duration, nil
+}
+
+func handleLog(config *Config) error {
+	at, args := extractFlag(os.Args[2:], "--at")
+	listName, args := extractFlag(args, "--list")
+	if len(args) < 2 {
+		return newError(errInvalidArgument, "usage: tgo log <number|id> <duration> [--at <time>]")
+	}
+
+	duration, err := parseDurationArg(strings.Join(args[1:], ""))
+	if err != nil {
+		return err
+	}
+
+	start, end, err := sessionBounds(duration, at, time.Now())
+	if err != nil {
+		return err
+	}
+
+	return runTaskCommand(config, "log", listName, args[0], func(taskList *TaskList, taskNum int) error {
+		return logSession(taskList, taskNum, start, end)
+	})
+}
+
+func handleSession(config *Config) error {
+	listName, args := extractFlag(os.Args[2:], "--list")
+	startSpec, args := extractFlag(args, "--start")
+	endSpec, args := extractFlag(args, "--end")
+	durationSpec, args := extractFlag(args, "--duration")
+
+	action := "list"
+	if len(args) > 0 && (args[0] == "list" || args[0] == "edit" || args[0] == "rm") {
+		action, args = args[0], args[1:]
+	}
+	if len(args) < 1 {
+		return newError(errInvalidArgument, "task number or ID required")
+	}
+
+	if action == "list" {
+		taskFile, taskList, err := openTaskList(config, listName)
+		if err != nil {
+			return err
+		}
+		taskNum, err := resolveTask(taskList, args[0])
+		if err != nil {
+			return err
+		}
+		if jsonOutput {
+			return printTaskResult("sessions", taskFile, taskList, taskList.Items[taskNum-1].ID)
+		}
+		displaySessions(&taskList.Items[taskNum-1])
+		return nil
+	}
+
+	if len(args) < 2 {
+		return newError(errInvalidArgument, "session number required")
+	}
+	sessionNum, err := strconv.Atoi(args[1])
+	if err != nil {
+		return newError(errInvalidArgument, "'%s' is not a valid session number", args[1])
+	}
+
+	if action == "rm" {
+		return runTaskCommand(config, "session-rm", listName, args[0], func(taskList *TaskList, taskNum int) error {
+			return removeSession(taskList, taskNum, sessionNum)
+		})
+	}
+
+	now := time.Now()
+	var start, end *time.Time
+	if startSpec != "" {
+		t, err := parseTimeSpec(startSpec, now)
+		if err != nil {
+			return err
+		}
+		start = &t
+	}
+	if endSpec != "" {
+		t, err := parseTimeSpec(endSpec, now)
+		if err != nil {
+			return err
+		}
+		end = &t
+	}
+	var duration time.Duration
+	if durationSpec != "" {
+		if duration, err = parseDurationArg(durationSpec); err != nil {
+			return err
+		}
+	}
+
+	return runTaskCommand(config, "session-edit", listName, args[0], func(taskList *TaskList, taskNum int) error {
+		return editSession(taskList, taskNum, sessionNum, start, end, duration)
+	})
+}
+
+func handleLogTask(arg string, taskList *TaskList, taskFile string) {
+	fields := strings.Fields(arg)
+	if len(fields) < 2 {
+		fmt.Println("❌ Usage: log <number|id> <duration> [start time]")
+		return
+	}
+
+	duration, err := parseDurationArg(fields[1])
+	if err != nil {
+		fmt.Printf("❌ %v\n", err)
+		return
+	}
+
+	start, end, err := sessionBounds(duration, strings.Join(fields[2:], " "), time.Now())
+	if err != nil {
+		fmt.Printf("❌ %v\n", err)
+		return
+	}
+
+	err = updateTask(taskFile, taskList, fields[0], func(taskList *TaskList, taskNum int) error {
+		return logSession(taskList, taskNum, start, end)
+	})
+	if err != nil {
+		fmt.Printf("❌ %v\n", err)
+	}
+}
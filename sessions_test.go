@@ -0,0 +1,85 @@
+package main
+
+import (
+	"io"
+	"testing"
+	"time"
+)
+
+func TestSessionsRejectOverlaps(t *testing.T) {
+	notices = io.Discard
+	at := func(hour, minute int) time.Time {
+		return time.Date(2026, time.October, 14, hour, minute, 0, 0, time.Local)
+	}
+	running := at(16, 0)
+	newList := func() *TaskList {
+		return &TaskList{Items: []Task{{
+			ID:     1,
+			Title:  "Review",
+			Status: StatusActive,
+			Sessions: []Session{
+				{StartTime: at(10, 0), EndTime: at(11, 0)},
+				{StartTime: at(13, 0), EndTime: at(14, 0)},
+			},
+			ActiveStartTime: &running,
+		}}}
+	}
+
+	logs := []struct {
+		name       string
+		start, end time.Time
+		conflict   bool
+	}{
+		{"before", at(8, 0), at(9, 0), false},
+		{"touching both", at(11, 0), at(13, 0), false},
+		{"inside a gap", at(11, 30), at(12, 30), false},
+		{"overlapping the start", at(9, 30), at(10, 30), true},
+		{"overlapping the end", at(10, 30), at(11, 30), true},
+		{"covering a session", at(12, 0), at(15, 0), true},
+		{"inside a session", at(13, 15), at(13, 45), true},
+		{"up to the running timer", at(15, 0), at(16, 0), false},
+		{"into the running timer", at(15, 0), at(16, 30), true},
+	}
+	for _, test := range logs {
+		t.Run("log "+test.name, func(t *testing.T) {
+			taskList := newList()
+			err := logSession(taskList, 1, test.start, test.end)
+			if test.conflict {
+				if kindOf(err) != errConflict {
+					t.Fatalf("logSession = %v, want a conflict", err)
+				}
+				if len(taskList.Items[0].Sessions) != 2 {
+					t.Errorf("logSession kept the overlapping session")
+				}
+			} else if err != nil {
+				t.Fatalf("logSession = %v, want no error", err)
+			}
+		})
+	}
+
+	edits := []struct {
+		name       string
+		session    int
+		start, end time.Time
+		conflict   bool
+	}{
+		{"within its own span", 1, at(10, 15), at(10, 45), false},
+		{"up to the next session", 1, at(10, 0), at(13, 0), false},
+		{"into the next session", 1, at(10, 0), at(13, 30), true},
+		{"into the previous session", 2, at(10, 30), at(14, 0), true},
+		{"into the running timer", 2, at(13, 0), at(16, 15), true},
+	}
+	for _, test := range edits {
+		t.Run("edit "+test.name, func(t *testing.T) {
+			taskList := newList()
+			err := editSession(taskList, 1, test.session, &test.start, &test.end, 0)
+			if test.conflict {
+				if kindOf(err) != errConflict {
+					t.Fatalf("editSession = %v, want a conflict", err)
+				}
+			} else if err != nil {
+				t.Fatalf("editSession = %v, want no error", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,212 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const stateFile = ".tgo-state.json"
+
+type runningTask struct {
+	List           string `json:"list"`
+	ShortID        string `json:"short_id"`
+	RunningSeconds int64  `json:"running_seconds"`
+	TotalSeconds   int64  `json:"total_seconds"`
+	Task           Task   `json:"task"`
+}
+
+type statusResult struct {
+	OK      bool          `json:"ok"`
+	Command string        `json:"command"`
+	Running []runningTask `json:"running"`
+}
+
+func loadState(statePath string) *State {
+	var state State
+	if readJSONFile(statePath, &state) != nil {
+		return &State{}
+	}
+	return &state
+}
+
+func saveState(statePath string, state *State) error {
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(statePath, data, 0644)
+}
+
+func activeTaskIDs(taskList *TaskList) map[int64]bool {
+	ids := map[int64]bool{}
+	for _, task := range taskList.Items {
+		if task.IsActive() {
+			ids[task.ID] = true
+		}
+	}
+	return ids
+}
+
+// syncRunningTimer records the task that was just started in the state
+// file, pausing whatever was running in another list, and forgets the
+// running task once its list no longer has it active.
+func syncRunningTimer(filePath string, taskList *TaskList, started int64, ends sessionEnds) error {
+	dir, listFile := filepath.Dir(filePath), filepath.Base(filePath)
+	statePath := filepath.Join(dir, stateFile)
+
+	unlock, err := lockFile(statePath)
+	if err != nil {
+		return withKind(errIO, err)
+	}
+	defer unlock()
+
+	state := loadState(statePath)
+	running := state.Running
+	if started == 0 && running != nil && running.List == listFile && activeTaskIDs(taskList)[running.TaskID] {
+		return nil
+	}
+
+	switch {
+	case started != 0:
+		if err := pauseOtherTimers(dir, listFile, running, ends); err != nil {
+			return err
+		}
+		state.Running = runningTimer(listFile, taskList, started)
+	case running == nil:
+		for id := range activeTaskIDs(taskList) {
+			state.Running = runningTimer(listFile, taskList, id)
+		}
+		if state.Running == nil {
+			return nil
+		}
+	case running.List == listFile:
+		state.Running = nil
+	default:
+		return nil
+	}
+
+	if err := saveState(statePath, state); err != nil {
+		return newError(errIO, "cannot save timer state: %v", err)
+	}
+	return nil
+}
+
+func runningTimer(listFile string, taskList *TaskList, id int64) *RunningTimer {
+	taskNum, err := findTaskByID(taskList, id)
+	if err != nil || taskList.Items[taskNum-1].ActiveStartTime == nil {
+		return nil
+	}
+	return &RunningTimer{List: listFile, TaskID: id, StartedAt: *taskList.Items[taskNum-1].ActiveStartTime}
+}
+
+// pauseOtherTimers stops the timer recorded in the state file, or any
+// timer running in another list when the state file has none yet.
+func pauseOtherTimers(dir, listFile string, running *RunningTimer, ends sessionEnds) error {
+	if running != nil {
+		if running.List == listFile {
+			return nil
+		}
+		return pauseRunningTimer(filepath.Join(dir, running.List), running.TaskID, ends)
+	}
+
+	taskFiles, err := findTaskFiles(dir)
+	if err != nil {
+		return nil
+	}
+	for _, file := range taskFiles {
+		if file == listFile {
+			continue
+		}
+		taskList, err := loadTasks(filepath.Join(dir, file))
+		if err != nil {
+			continue
+		}
+		for id := range activeTaskIDs(taskList) {
+			if err := pauseRunningTimer(filepath.Join(dir, file), id, ends); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func pauseRunningTimer(filePath string, id int64, ends sessionEnds) error {
+	taskList, err := loadTasks(filePath)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return newError(errIO, "cannot load %s: %v", filepath.Base(filePath), err)
+	}
+
+	return modifyTasks(filePath, taskList, func(taskList *TaskList) error {
+		taskNum, err := findTaskByID(taskList, id)
+		if err != nil || !taskList.Items[taskNum-1].IsActive() {
+			return nil
+		}
+
+		task := &taskList.Items[taskNum-1]
+		starts := map[int64]time.Time{id: *task.ActiveStartTime}
+		stopTaskTimer(task, time.Now())
+		trimSessions(taskList, starts, ends)
+		fmt.Fprintf(notices, "⏸️ Paused: %s in %s [Total: %s]\n", task.Title, taskList.Title, task.GetFormattedDuration())
+		return nil
+	})
+}
+
+func handleStatus(config *Config) error {
+	if config.TaskDir == "" {
+		return errNoTaskDir
+	}
+
+	taskFiles, err := findTaskFiles(config.TaskDir)
+	if err != nil {
+		return err
+	}
+
+	running := []runningTask{}
+	for _, file := range taskFiles {
+		taskList, err := loadTasks(filepath.Join(config.TaskDir, file))
+		if err != nil {
+			return newError(errIO, "cannot load %s: %v", file, err)
+		}
+		for i := range taskList.Items {
+			task := &taskList.Items[i]
+			if !task.IsActive() || task.ActiveStartTime == nil {
+				continue
+			}
+			running = append(running, runningTask{
+				List:           strings.TrimSuffix(file, ".json"),
+				ShortID:        shortID(taskList, task),
+				RunningSeconds: int64(time.Since(*task.ActiveStartTime).Seconds()),
+				TotalSeconds:   int64(time.Duration(task.TotalDuration).Seconds()),
+				Task:           *task,
+			})
+		}
+	}
+
+	if jsonOutput {
+		printJSON(statusResult{OK: true, Command: "status", Running: running})
+		return nil
+	}
+
+	if len(running) == 0 {
+		fmt.Println("⏸️ No timer running")
+		return nil
+	}
+	for _, entry := range running {
+		fmt.Printf("🟢 %s %s in %s [Running: %s] [Total: %s]\n",
+			entry.ShortID, entry.Task.Title, entry.List,
+			formatDuration(entry.RunningSeconds*int64(time.Second)),
+			formatDuration(entry.TotalSeconds*int64(time.Second)))
+	}
+	if len(running) > 1 {
+		fmt.Printf("⚠️ %d timers are running, starting a task pauses the others\n", len(running))
+	}
+	return nil
+}
@@ -0,0 +1,228 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+// askCloseSubtasks asks whether open subtasks are completed along with
+// their parent. The TUI replaces it while the terminal is in raw mode.
+var askCloseSubtasks = promptCloseSubtasks
+
+// parentIndex returns the index of task's parent in the list, or -1 for
+// top-level tasks and subtasks whose parent has been removed.
+func parentIndex(taskList *TaskList, task *Task) int {
+	if task.ParentID == 0 {
+		return -1
+	}
+	for i := range taskList.Items {
+		if taskList.Items[i].ID == task.ParentID {
+			return i
+		}
+	}
+	return -1
+}
+
+// subtasks returns the direct children of the task with id, in display
+// order.
+func subtasks(taskList *TaskList, id int64) []int {
+	var children []int
+	for _, i := range tasksWithStatus(taskList, StatusActive, StatusPending, StatusPaused, StatusDone) {
+		if taskList.Items[i].ParentID == id {
+			children = append(children, i)
+		}
+	}
+	return children
+}
+
+// descendants returns the subtasks of the task with id at any depth.
+func descendants(taskList *TaskList, id int64) []int {
+	var all []int
+	seen := map[int64]bool{id: true}
+	queue := []int64{id}
+	for len(queue) > 0 {
+		parent := queue[0]
+		queue = queue[1:]
+		for _, i := range subtasks(taskList, parent) {
+			if child := taskList.Items[i].ID; !seen[child] {
+				seen[child] = true
+				all = append(all, i)
+				queue = append(queue, child)
+			}
+		}
+	}
+	return all
+}
+
+func taskDepth(taskList *TaskList, task *Task) int {
+	depth := 0
+	for i := parentIndex(taskList, task); i >= 0 && depth < len(taskList.Items); i = parentIndex(taskList, &taskList.Items[i]) {
+		depth++
+	}
+	return depth
+}
+
+// nestTasks keeps the top-level tasks of indexes and places each task's
+// subtasks right after it, so they are shown under their parent whatever
+// their own status.
+func nestTasks(taskList *TaskList, indexes []int) []int {
+	var nested []int
+	var appendTree func(index int, depth int)
+	appendTree = func(index int, depth int) {
+		nested = append(nested, index)
+		if depth < len(taskList.Items) {
+			for _, child := range subtasks(taskList, taskList.Items[index].ID) {
+				appendTree(child, depth+1)
+			}
+		}
+	}
+
+	for _, i := range indexes {
+		if parentIndex(taskList, &taskList.Items[i]) < 0 {
+			appendTree(i, 0)
+		}
+	}
+	return nested
+}
+
+// subtaskIndent prefixes subtasks with one step per level of nesting.
+func subtaskIndent(taskList *TaskList, task *Task) string {
+	depth := taskDepth(taskList, task)
+	if depth == 0 {
+		return ""
+	}
+	return strings.Repeat("   ", depth-1) + "↳ "
+}
+
+// trackedTime is the task's total including a running session.
+func trackedTime(task *Task) int64 {
+	total := task.TotalDuration
+	if task.ActiveStartTime != nil {
+		total += time.Since(*task.ActiveStartTime).Nanoseconds()
+	}
+	return total
+}
+
+// subtaskInfo rolls the completion and tracked time of a task's subtasks
+// up into the parent.
+func subtaskInfo(taskList *TaskList, task *Task) string {
+	children := descendants(taskList, task.ID)
+	if len(children) == 0 {
+		return ""
+	}
+
+	done := 0
+	total := trackedTime(task)
+	for _, i := range children {
+		if taskList.Items[i].IsDone() {
+			done++
+		}
+		total += trackedTime(&taskList.Items[i])
+	}
+
+	info := fmt.Sprintf(" [☑ %d/%d (%d%%)", done, len(children), done*100/len(children))
+	if total > trackedTime(task) {
+		info += " │ Σ " + formatDuration(total)
+	}
+	return info + "]"
+}
+
+func addSubtask(taskList *TaskList, parentID int64, input taskInput) (*Task, error) {
+	if _, err := findTaskByID(taskList, parentID); err != nil {
+		return nil, err
+	}
+	task := addTask(taskList, input)
+	task.ParentID = parentID
+	return task, nil
+}
+
+// finishTask marks a task done, stopping its timer if it is running.
+func finishTask(task *Task, now time.Time) {
+	if task.Status == StatusActive {
+		stopTaskTimer(task, now)
+	}
+	task.Status = StatusDone
+	task.CompletedAt = &now
+}
+
+func openSubtasks(taskList *TaskList, id int64) []int {
+	var open []int
+	for _, i := range descendants(taskList, id) {
+		if !taskList.Items[i].IsDone() {
+			open = append(open, i)
+		}
+	}
+	return open
+}
+
+// confirmCloseSubtasks asks, before the list is locked, whether completing
+// a task should complete its open subtasks too. Without a terminal to ask
+// they are left open.
+func confirmCloseSubtasks(taskList *TaskList, taskNum int) bool {
+	task := &taskList.Items[taskNum-1]
+	open := openSubtasks(taskList, task.ID)
+	if len(open) == 0 || jsonOutput || !isTerminal(os.Stdin) {
+		return false
+	}
+	return askCloseSubtasks(task, len(open))
+}
+
+// completeTask returns the change that marks a task done, completing its
+// open subtasks too when withSubtasks is set.
+func completeTask(withSubtasks bool) func(*TaskList, int) error {
+	return func(taskList *TaskList, index int) error {
+		return markTaskComplete(taskList, index, withSubtasks)
+	}
+}
+
+// closeSubtasks runs after a parent is completed and either completes its
+// open subtasks or reports that they were left open.
+func closeSubtasks(taskList *TaskList, task *Task, now time.Time, withSubtasks bool) {
+	open := openSubtasks(taskList, task.ID)
+	if len(open) == 0 {
+		return
+	}
+
+	if !withSubtasks {
+		fmt.Fprintf(notices, "⚠️ Left %d subtask(s) of %s open\n", len(open), task.Title)
+		return
+	}
+
+	for _, i := range open {
+		finishTask(&taskList.Items[i], now)
+	}
+	fmt.Fprintf(notices, "✅ Completed %d subtask(s) of %s\n", len(open), task.Title)
+}
+
+func promptCloseSubtasks(task *Task, open int) bool {
+	fmt.Printf("\n⚠️ %s has %d open subtask(s). Complete them too? (y/N): ", task.Title, open)
+	line, _ := readLine()
+	answer := strings.ToLower(strings.TrimSpace(line))
+	return answer == "y" || answer == "yes"
+}
+
+func handleSubtaskTask(arg string, taskList *TaskList, taskFile string) {
+	parentRef, text, _ := strings.Cut(arg, " ")
+	input, err := parseTaskInput(text)
+	if err != nil {
+		fmt.Printf("❌ %v\n", err)
+		return
+	}
+
+	parentNum, err := resolveTask(taskList, parentRef)
+	if err != nil {
+		fmt.Printf("❌ %v\n", err)
+		return
+	}
+
+	parentID := taskList.Items[parentNum-1].ID
+	err = updateTasks(taskFile, taskList, func(taskList *TaskList) error {
+		_, err := addSubtask(taskList, parentID, input)
+		return err
+	})
+	if err != nil {
+		fmt.Printf("❌ %v\n", err)
+	}
+}
@@ -0,0 +1,187 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// normalizeTag accepts "#client-a" or "client-a" and returns "client-a".
+// Contexts such as "@phone" keep their "@" so they stay apart from other
+// tags. Purely numeric words such as issue references (#123) are not tags.
+func normalizeTag(word string) (string, bool) {
+	tag, context := strings.CutPrefix(word, "@")
+	if !context {
+		tag = strings.TrimPrefix(tag, "#")
+	}
+	tag = strings.ToLower(tag)
+	if tag == "" {
+		return "", false
+	}
+
+	digits := true
+	for _, r := range tag {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && !strings.ContainsRune("-_/.", r) {
+			return "", false
+		}
+		digits = digits && unicode.IsDigit(r)
+	}
+	if context {
+		tag = "@" + tag
+	}
+	return tag, !digits
+}
+
+// tagWord writes a tag the way it is typed: #tag, or @context.
+func tagWord(tag string) string {
+	if strings.HasPrefix(tag, "@") {
+		return tag
+	}
+	return "#" + tag
+}
+
+// splitTags separates #tag and @context words from the rest of a title.
+func splitTags(words []string) ([]string, []string) {
+	var rest, tags []string
+	for _, word := range words {
+		if strings.HasPrefix(word, "#") || strings.HasPrefix(word, "@") {
+			if tag, ok := normalizeTag(word); ok {
+				if !slices.Contains(tags, tag) {
+					tags = append(tags, tag)
+				}
+				continue
+			}
+		}
+		rest = append(rest, word)
+	}
+	return rest, tags
+}
+
+func hasTag(task *Task, tag string) bool {
+	return slices.Contains(task.Tags, tag)
+}
+
+func setTaskTags(taskList *TaskList, index int, tags []string) error {
+	if index < 1 || index > len(taskList.Items) {
+		return newError(errNotFound, "invalid task number. Use 1-%d", len(taskList.Items))
+	}
+
+	task := &taskList.Items[index-1]
+	task.Tags = tags
+	if len(tags) == 0 {
+		fmt.Fprintf(notices, "🏷️ Cleared tags: %s\n", task.Title)
+	} else {
+		fmt.Fprintf(notices, "🏷️ Tagged: %s\n", taskLabel(task))
+	}
+	return nil
+}
+
+// editTags adds each tag argument to current and removes those written
+// as -tag.
+func editTags(current []string, args []string) ([]string, error) {
+	tags := slices.Clone(current)
+	for _, arg := range args {
+		remove := strings.HasPrefix(arg, "-")
+		tag, ok := normalizeTag(strings.TrimPrefix(strings.TrimPrefix(arg, "-"), "+"))
+		if !ok {
+			return nil, newError(errInvalidArgument, "invalid tag '%s'", arg)
+		}
+
+		index := slices.Index(tags, tag)
+		switch {
+		case remove && index >= 0:
+			tags = slices.Delete(tags, index, index+1)
+		case !remove && index < 0:
+			tags = append(tags, tag)
+		}
+	}
+	return tags, nil
+}
+
+func handleTag(config *Config) error {
+	listName, args := extractFlag(os.Args[2:], "--list")
+	if len(args) < 2 {
+		return newError(errInvalidArgument, "usage: tgo tag <number|id> <tag>... (-tag removes)")
+	}
+
+	return runTaskCommand(config, "tag", listName, args[0], func(taskList *TaskList, taskNum int) error {
+		tags, err := editTags(taskList.Items[taskNum-1].Tags, args[1:])
+		if err != nil {
+			return err
+		}
+		return setTaskTags(taskList, taskNum, tags)
+	})
+}
+
+func handleTagTask(arg string, taskList *TaskList, taskFile string) {
+	fields := strings.Fields(arg)
+	if len(fields) < 2 {
+		fmt.Println("❌ Usage: tag <number|id> <tag>... (-tag removes)")
+		return
+	}
+
+	err := updateTask(taskFile, taskList, fields[0], func(taskList *TaskList, taskNum int) error {
+		tags, err := editTags(taskList.Items[taskNum-1].Tags, fields[1:])
+		if err != nil {
+			return err
+		}
+		return setTaskTags(taskList, taskNum, tags)
+	})
+	if err != nil {
+		fmt.Printf("❌ %v\n", err)
+	}
+}
+
+// handleFilter sets or, without a tag, clears the interactive tag filter.
+func handleFilter(arg string, opts *DisplayOptions) {
+	if arg == "" {
+		opts.Tag = ""
+		return
+	}
+	tag, ok := normalizeTag(arg)
+	if !ok {
+		fmt.Printf("❌ Invalid tag '%s'\n", arg)
+		return
+	}
+	opts.Tag = tag
+}
+
+// listTagged prints the tasks carrying tag across every list.
+func listTagged(config *Config, tag string, taskFiles []string) error {
+	opts := DisplayOptions{Tag: tag}
+	var summaries []listSummary
+	for _, file := range taskFiles {
+		taskList, err := loadTasks(filepath.Join(config.TaskDir, file))
+		if err != nil {
+			return newError(errIO, "cannot load %s: %v", file, err)
+		}
+
+		var tasks []Task
+		for _, i := range opts.tasks(taskList, StatusActive, StatusPending, StatusPaused, StatusDone) {
+			tasks = append(tasks, taskList.Items[i])
+		}
+		if len(tasks) == 0 {
+			continue
+		}
+		summaries = append(summaries, summarizeList(file, taskList, tasks))
+
+		if !jsonOutput {
+			displayTaskList(taskList, file, opts)
+		}
+	}
+
+	if jsonOutput {
+		if summaries == nil {
+			summaries = []listSummary{}
+		}
+		printJSON(listsResult{OK: true, Command: "list", Lists: summaries})
+		return nil
+	}
+	if len(summaries) == 0 {
+		fmt.Printf("🏷️ No tasks tagged %s\n", tagWord(tag))
+	}
+	return nil
+}
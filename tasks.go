@@ -1,11 +1,13 @@
 package main
 
 import (
-	"bufio"
+	"crypto/sha256"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -13,24 +15,24 @@ import (
 
 func findTaskFiles(folder string) ([]string, error) {
 	if folder == "" {
-		return nil, fmt.Errorf("no task folder configured")
+		return nil, newError(errInvalidArgument, "no task folder configured")
 	}
 
 	entries, err := os.ReadDir(folder)
 	if err != nil {
-		return nil, fmt.Errorf("cannot read folder %s: %v", folder, err)
+		return nil, newError(errIO, "cannot read folder %s: %v", folder, err)
 	}
 
 	var taskFiles []string
 	for _, entry := range entries {
-		if !entry.IsDir() && strings.HasSuffix(entry.Name(), ".json") {
+		if !entry.IsDir() && strings.HasSuffix(entry.Name(), ".json") && !strings.HasPrefix(entry.Name(), ".") {
 			taskFiles = append(taskFiles, entry.Name())
 		}
 	}
 
 	if len(taskFiles) == 0 {
 		
-		return nil, fmt.Errorf("no task lists found")
+		return nil, newError(errNotFound, "no task lists found")
 	}
 
 	return taskFiles, nil
@@ -43,13 +45,13 @@ func selectTaskFile(folder string, taskFiles []string) (string, error) {
 		fmt.Printf("  %d. %s\n", i+1, displayName)
 	}
 
-	scanner := bufio.NewScanner(os.Stdin)
 	for {
 		fmt.Printf("\nSelect list (1-%d), create 'c <name>', or remove 'r <number>': ", len(taskFiles))
-		if !scanner.Scan() {
-			return "", fmt.Errorf("input error")
+		line, ok := readLine()
+		if !ok {
+			return "", newError(errIO, "input error")
 		}
-		input := strings.TrimSpace(scanner.Text())
+		input := strings.TrimSpace(line)
 
 		if strings.HasPrefix(input, "c ") {
 			listName := strings.TrimSpace(input[2:])
@@ -79,8 +81,8 @@ func selectTaskFile(folder string, taskFiles []string) (string, error) {
 			}
 			selectedFile := taskFiles[choice-1]
 			fmt.Printf("Remove '%s'? (y/N): ", selectedFile)
-			if scanner.Scan() && strings.ToLower(scanner.Text()) == "y" {
-				if err := os.Remove(filepath.Join(folder, selectedFile)); err != nil {
+			if line, ok := readLine(); ok && strings.ToLower(line) == "y" {
+				if err := removeListFile(filepath.Join(folder, selectedFile)); err != nil {
 					fmt.Printf("❌ Failed to remove: %v\n", err)
 					continue
 				}
@@ -90,7 +92,7 @@ func selectTaskFile(folder string, taskFiles []string) (string, error) {
 					return "", err
 				}
 				if len(taskFiles) == 0 {
-					return "", fmt.Errorf("no task lists found")
+					return "", newError(errNotFound, "no task lists found")
 				}
 				displayTaskFiles(taskFiles)
 			}
@@ -119,10 +121,21 @@ func loadTasks(filePath string) (*TaskList, error) {
 	if err != nil {
 		return nil, err
 	}
-
 	var taskList TaskList
-	err = json.Unmarshal(data, &taskList)
-	return &taskList, err
+	if err = json.Unmarshal(data, &taskList); err == nil {
+		taskList.checksum = sha256.Sum256(data)
+		warnInvalid(filePath, &taskList)
+		return &taskList, nil
+	}
+
+	var backup TaskList
+	if readJSONFile(filePath+backupSuffix, &backup) != nil {
+		return nil, err
+	}
+	fmt.Fprintf(warnings, "⚠️ Could not load %s (%v), using backup\n", filepath.Base(filePath), err)
+	backup.checksum = sha256.Sum256(data)
+	warnInvalid(filePath, &backup)
+	return &backup, nil
 }
 
 func saveTasks(filePath string, taskList *TaskList) error {
@@ -131,16 +144,126 @@ func saveTasks(filePath string, taskList *TaskList) error {
 	if err != nil {
 		return err
 	}
-	return os.WriteFile(filePath, data, 0644)
+	if err := writeFileAtomic(filePath, data, 0644); err != nil {
+		return err
+	}
+	taskList.checksum = sha256.Sum256(data)
+	return nil
+}
+
+func changedOnDisk(filePath string, taskList *TaskList) bool {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return false
+	}
+	return sha256.Sum256(data) != taskList.checksum
+}
+
+func updateTasks(filePath string, taskList *TaskList, apply func(*TaskList) error) error {
+	var started int64
+	var resolved []int64
+	ends := planSessionEnds(filePath, taskList, apply)
+	err := modifyTasks(filePath, taskList, func(taskList *TaskList) error {
+		before, open, starts := activeTaskIDs(taskList), openTaskIDs(taskList), activeStarts(taskList)
+		if err := apply(taskList); err != nil {
+			return err
+		}
+		trimSessions(taskList, starts, ends)
+		for id := range activeTaskIDs(taskList) {
+			if !before[id] {
+				started = id
+			}
+		}
+		resolved = resolvedTasks(open, taskList)
+		unblockTasks(taskList, resolved)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if len(resolved) > 0 {
+		if err := unblockOtherLists(filePath, resolved); err != nil {
+			return err
+		}
+	}
+	return syncRunningTimer(filePath, taskList, started, ends)
+}
+
+// modifyTasks applies a change under the list's lock, reloading first
+// if another process has saved the list since it was loaded.
+func modifyTasks(filePath string, taskList *TaskList, apply func(*TaskList) error) error {
+	unlock, err := lockFile(filePath)
+	if err != nil {
+		return withKind(errIO, err)
+	}
+	defer unlock()
+
+	if changedOnDisk(filePath, taskList) {
+		fresh, err := loadTasks(filePath)
+		if err != nil {
+			return newError(errIO, "load error: %v", err)
+		}
+		*taskList = *fresh
+		fmt.Fprintln(notices, "🔄 List changed on disk, reloaded")
+	}
+
+	if err := apply(taskList); err != nil {
+		return err
+	}
+	if err := saveTasks(filePath, taskList); err != nil {
+		return newError(errIO, "save error: %v", err)
+	}
+	return nil
+}
+
+func updateTask(filePath string, taskList *TaskList, taskRef string, apply func(*TaskList, int) error) error {
+	taskNum, err := resolveTask(taskList, taskRef)
+	if err != nil {
+		return err
+	}
+
+	return updateTaskByID(filePath, taskList, taskList.Items[taskNum-1].ID, apply)
+}
+
+func updateTaskByID(filePath string, taskList *TaskList, id int64, apply func(*TaskList, int) error) error {
+	return updateTasks(filePath, taskList, func(taskList *TaskList) error {
+		taskNum, err := findTaskByID(taskList, id)
+		if err != nil {
+			return err
+		}
+		return apply(taskList, taskNum)
+	})
 }
 
 func createNewList(folder string, listName string) error {
 	if strings.TrimSpace(listName) == "" {
-		return fmt.Errorf("list name cannot be empty")
+		return newError(errInvalidArgument, "list name cannot be empty")
+	}
+
+	fileName := fmt.Sprintf("%s.json", sanitizeListName(listName))
+	filePath := filepath.Join(folder, fileName)
+
+	if _, err := os.Stat(filePath); err == nil {
+		return newError(errConflict, "list '%s' already exists", listName)
+	}
+
+	now := time.Now()
+	newTaskList := &TaskList{
+		Title:     listName,
+		Items:     []Task{},
+		CreatedAt: now,
+		UpdatedAt: now,
 	}
 
-	sanitizedName := strings.ToLower(listName)
-	sanitizedName = strings.Map(func(r rune) rune {
+	if err := saveTasks(filePath, newTaskList); err != nil {
+		return withKind(errIO, err)
+	}
+	return nil
+}
+
+func sanitizeListName(listName string) string {
+	sanitizedName := strings.ToLower(strings.TrimSpace(listName))
+	return strings.Map(func(r rune) rune {
 		if r == ' ' {
 			return '-'
 		}
@@ -151,30 +274,116 @@ func createNewList(folder string, listName string) error {
 		}
 		return -1
 	}, sanitizedName)
+}
 
-	fileName := fmt.Sprintf("%s.json", sanitizedName)
-	filePath := filepath.Join(folder, fileName)
+func findListFile(folder string, listName string) (string, error) {
+	taskFiles, err := findTaskFiles(folder)
+	if err != nil {
+		return "", err
+	}
 
-	if _, err := os.Stat(filePath); err == nil {
-		return fmt.Errorf("list '%s' already exists", listName)
+	wanted := strings.TrimSuffix(strings.TrimSpace(listName), ".json")
+	sanitized := sanitizeListName(wanted)
+	for _, file := range taskFiles {
+		baseName := strings.TrimSuffix(file, ".json")
+		if baseName == wanted || (sanitized != "" && baseName == sanitized) {
+			return filepath.Join(folder, file), nil
+		}
 	}
 
-	now := time.Now()
-	newTaskList := &TaskList{
-		Title:     listName,
-		Items:     []Task{},
-		CreatedAt: now,
-		UpdatedAt: now,
+	for _, file := range taskFiles {
+		filePath := filepath.Join(folder, file)
+		taskList, err := loadTasks(filePath)
+		if err != nil {
+			continue
+		}
+		if strings.EqualFold(strings.TrimSpace(taskList.Title), wanted) {
+			return filePath, nil
+		}
 	}
 
-	return saveTasks(filePath, newTaskList)
+	return "", newError(errNotFound, "list '%s' not found", listName)
+}
+
+func displayTaskList(taskList *TaskList, fileName string, opts DisplayOptions) {
+	renderTaskList(os.Stdout, taskList, fileName, opts)
 }
 
-func displayTaskList(taskList *TaskList, fileName string) {
+func renderTaskList(w io.Writer, taskList *TaskList, fileName string, opts DisplayOptions) {
 	listName := strings.TrimSuffix(fileName, ".json")
-	fmt.Printf("┌─ 📋 %s\n", listName)
-	fmt.Printf("├─ %s\n", strings.Repeat("─", len(listName)+4))
+	fmt.Fprintf(w, "┌─ 📋 %s\n", listName)
+	fmt.Fprintf(w, "├─ %s\n", strings.Repeat("─", len(listName)+4))
+
+	activeCount, pendingCount, doneCount := countTasks(taskList)
+
+	fmt.Fprintf(w, "├─ Active: %d │ Pending: %d │ Done: %d\n", activeCount, pendingCount, doneCount)
+	if opts.Tag != "" {
+		fmt.Fprintf(w, "├─ 🏷️ Filter: %s\n", tagWord(opts.Tag))
+	}
+	fmt.Fprintf(w, "└─ %s\n\n", strings.Repeat("─", 40))
+
+	if statuses := opts.filter(StatusActive); len(nestTasks(taskList, opts.tasks(taskList, statuses...))) > 0 {
+		fmt.Fprintln(w, "🔴 ACTIVE TASKS:")
+		renderTasksByStatus(w, taskList, opts, statuses...)
+		fmt.Fprintln(w)
+	}
+
+	if statuses := opts.filter(StatusPending, StatusPaused); len(nestTasks(taskList, opts.tasks(taskList, statuses...))) > 0 {
+		fmt.Fprintln(w, "⏸️ PENDING TASKS:")
+		renderTasksByStatus(w, taskList, opts, statuses...)
+		fmt.Fprintln(w)
+	}
+
+	if statuses := opts.filter(StatusDone); len(nestTasks(taskList, opts.tasks(taskList, statuses...))) > 0 {
+		fmt.Fprintln(w, "✅ COMPLETED TASKS:")
+		renderTasksByStatus(w, taskList, opts, statuses...)
+		fmt.Fprintln(w)
+	}
+
+	if !opts.ShowCommands {
+		return
+	}
+	fmt.Fprintln(w, "💡 Commands: <number|id> (start/stop), add <task>, sub <number|id> <task>, block <number|id> <number|id>, remove <number|id>, done <number|id>, u <number|id> (reopen), note <number|id> [text], prio <number|id> <level>, due <number|id> <date>, tag <number|id> <tag>, filter [#tag], notes (show/hide), r (return), q (quit)")
+}
+
+func renderTasksByStatus(w io.Writer, taskList *TaskList, opts DisplayOptions, statuses ...TaskStatus) {
+	idLength := shortIDLength(taskList)
+	for _, i := range nestTasks(taskList, opts.tasks(taskList, statuses...)) {
+		task := taskList.Items[i]
+		statusIcon, timeInfo := taskStatusInfo(&task)
+		indent := subtaskIndent(taskList, &task)
+
+		fmt.Fprintf(w, "  %s%d. %s %s %s%s%s%s%s\n", indent, i+1, task.Hash()[:idLength], statusIcon, taskLabel(&task), timeInfo,
+			taskDueInfo(&task, time.Now()), taskBlockedInfo(taskList, &task), subtaskInfo(taskList, &task))
+		if indent != "" {
+			indent = strings.Repeat(" ", len([]rune(indent)))
+		}
+
+		if len(task.Sessions) > 0 && (task.Status == StatusDone || task.Status == StatusPaused) {
+			fmt.Fprintf(w, "     %sSessions: %d │ ", indent, len(task.Sessions))
+			if len(task.Sessions) <= 3 {
+				for j, session := range task.Sessions {
+					fmt.Fprintf(w, "%s", formatDuration(session.Duration))
+					if j < len(task.Sessions)-1 {
+						fmt.Fprint(w, ", ")
+					}
+				}
+			} else {
+				for j := 0; j < 2; j++ {
+					fmt.Fprintf(w, "%s, ", formatDuration(task.Sessions[j].Duration))
+				}
+				fmt.Fprintf(w, "... +%d more", len(task.Sessions)-2)
+			}
+			fmt.Fprintln(w)
+		}
+
+		if task.Comment != "" && !opts.HideNotes {
+			renderNote(w, indent, task.Comment)
+		}
+	}
+}
 
+func countTasks(taskList *TaskList) (int, int, int) {
 	activeCount := 0
 	pendingCount := 0
 	doneCount := 0
@@ -189,97 +398,208 @@ func displayTaskList(taskList *TaskList, fileName string) {
 			doneCount++
 		}
 	}
+	return activeCount, pendingCount, doneCount
+}
 
-	fmt.Printf("├─ Active: %d │ Pending: %d │ Done: %d\n", activeCount, pendingCount, doneCount)
-	fmt.Printf("└─ %s\n\n", strings.Repeat("─", 40))
-
-	if activeCount > 0 {
-		fmt.Println("🔴 ACTIVE TASKS:")
-		displayTasksByStatus(taskList, StatusActive)
-		fmt.Println()
+func trackedDuration(taskList *TaskList) int64 {
+	var total int64
+	for i := range taskList.Items {
+		total += trackedTime(&taskList.Items[i])
 	}
+	return total
+}
 
-	if pendingCount > 0 {
-		fmt.Println("⏸️ PENDING TASKS:")
-		displayTasksByStatus(taskList, StatusPending, StatusPaused)
-		fmt.Println()
+func tasksWithStatus(taskList *TaskList, statuses ...TaskStatus) []int {
+	statusMap := make(map[TaskStatus]bool)
+	for _, status := range statuses {
+		statusMap[status] = true
 	}
 
-	if doneCount > 0 {
-		fmt.Println("✅ COMPLETED TASKS:")
-		displayTasksByStatus(taskList, StatusDone)
-		fmt.Println()
+	var indexes []int
+	for i, task := range taskList.Items {
+		if statusMap[task.Status] {
+			indexes = append(indexes, i)
+		}
 	}
 
-	fmt.Println("💡 Commands: <number> (start/stop), add <task>, remove <number>, done <number>, r (return), q (quit)")
+	sort.SliceStable(indexes, func(a, b int) bool {
+		taskA, taskB := &taskList.Items[indexes[a]], &taskList.Items[indexes[b]]
+		if rankA, rankB := priorityRank(taskA.Priority), priorityRank(taskB.Priority); rankA != rankB {
+			return rankA < rankB
+		}
+		return taskA.CreatedAt.Before(taskB.CreatedAt)
+	})
+	return indexes
 }
 
-func displayTasksByStatus(taskList *TaskList, statuses ...TaskStatus) {
-	statusMap := make(map[TaskStatus]bool)
-	for _, status := range statuses {
-		statusMap[status] = true
+func taskStatusInfo(task *Task) (string, string) {
+	var statusIcon string
+	var timeInfo string
+
+	switch task.Status {
+	case StatusActive:
+		statusIcon = "🟢"
+		if task.ActiveStartTime != nil {
+			elapsed := time.Since(*task.ActiveStartTime)
+			timeInfo = fmt.Sprintf(" [Running: %s]", formatDuration(elapsed.Nanoseconds()))
+		}
+	case StatusPending:
+		statusIcon = "⚪"
+		if task.TotalDuration > 0 {
+			timeInfo = fmt.Sprintf(" [Total: %s]", task.GetFormattedDuration())
+		}
+	case StatusPaused:
+		statusIcon = "🟡"
+		timeInfo = fmt.Sprintf(" [Paused: %s]", task.GetFormattedDuration())
+	case StatusDone:
+		statusIcon = "✅"
+		if task.TotalDuration > 0 {
+			timeInfo = fmt.Sprintf(" [Total: %s]", task.GetFormattedDuration())
+		}
+		if task.CompletedAt != nil {
+			timeInfo += fmt.Sprintf(" [Completed: %s]", task.CompletedAt.Format("15:04"))
+		}
 	}
 
-	for i, task := range taskList.Items {
-		if !statusMap[task.Status] {
-			continue
+	return statusIcon, timeInfo
+}
+
+func renderNote(w io.Writer, indent string, note string) {
+	for i, line := range strings.Split(note, "\n") {
+		if i == 0 {
+			fmt.Fprintf(w, "     %s📝 %s\n", indent, line)
+		} else {
+			fmt.Fprintf(w, "        %s%s\n", indent, line)
 		}
+	}
+}
 
-		var statusIcon string
-		var timeInfo string
+const minShortIDLength = 4
 
-		switch task.Status {
-		case StatusActive:
-			statusIcon = "🟢"
-			if task.ActiveStartTime != nil {
-				elapsed := time.Since(*task.ActiveStartTime)
-				timeInfo = fmt.Sprintf(" [Running: %s]", formatDuration(elapsed.Nanoseconds()))
-			}
-		case StatusPending:
-			statusIcon = "⚪"
-			if task.TotalDuration > 0 {
-				timeInfo = fmt.Sprintf(" [Total: %s]", task.GetFormattedDuration())
+func shortIDLength(taskList *TaskList) int {
+	length := minShortIDLength
+	for length < 40 {
+		seen := make(map[string]bool)
+		unique := true
+		for i := range taskList.Items {
+			prefix := taskList.Items[i].Hash()[:length]
+			if seen[prefix] {
+				unique = false
+				break
 			}
-		case StatusPaused:
-			statusIcon = "🟡"
-			timeInfo = fmt.Sprintf(" [Paused: %s]", task.GetFormattedDuration())
-		case StatusDone:
-			statusIcon = "✅"
-			if task.TotalDuration > 0 {
-				timeInfo = fmt.Sprintf(" [Total: %s]", task.GetFormattedDuration())
+			seen[prefix] = true
+		}
+		if unique {
+			break
+		}
+		length++
+	}
+	return length
+}
+
+func shortID(taskList *TaskList, task *Task) string {
+	return task.Hash()[:shortIDLength(taskList)]
+}
+
+func resolveTask(taskList *TaskList, ref string) (int, error) {
+	ref = strings.TrimSpace(ref)
+	if ref == "" {
+		return 0, newError(errInvalidArgument, "task number or ID required")
+	}
+
+	// Short IDs are at least minShortIDLength characters and may be all
+	// digits, so a ref that long (or with a leading zero) is looked up as
+	// an ID before it is taken as a position.
+	if len(ref) >= minShortIDLength || strings.HasPrefix(ref, "0") {
+		prefix := strings.ToLower(ref)
+		match := 0
+		for i := range taskList.Items {
+			if strings.HasPrefix(taskList.Items[i].Hash(), prefix) {
+				if match != 0 {
+					return 0, newError(errInvalidArgument, "ID '%s' is ambiguous", ref)
+				}
+				match = i + 1
 			}
-			if task.CompletedAt != nil {
-				timeInfo += fmt.Sprintf(" [Completed: %s]", task.CompletedAt.Format("15:04"))
+		}
+		if match != 0 {
+			return match, nil
+		}
+	}
+
+	num, numErr := strconv.Atoi(ref)
+	if numErr == nil && !strings.HasPrefix(ref, "0") && num >= 1 && num <= len(taskList.Items) {
+		return num, nil
+	}
+
+	if id, err := strconv.ParseInt(ref, 10, 64); err == nil {
+		for i := range taskList.Items {
+			if taskList.Items[i].ID == id {
+				return i + 1, nil
 			}
 		}
+	}
 
-		fmt.Printf("  %d. %s %s%s\n", i+1, statusIcon, task.Title, timeInfo)
+	if numErr == nil {
+		return 0, newError(errNotFound, "invalid task number. Use 1-%d", len(taskList.Items))
+	}
+	return 0, newError(errNotFound, "no task matches '%s'", ref)
+}
 
-		if len(task.Sessions) > 0 && (task.Status == StatusDone || task.Status == StatusPaused) {
-			fmt.Printf("     Sessions: %d │ ", len(task.Sessions))
-			if len(task.Sessions) <= 3 {
-				for j, session := range task.Sessions {
-					fmt.Printf("%s", formatDuration(session.Duration))
-					if j < len(task.Sessions)-1 {
-						fmt.Print(", ")
-					}
-				}
-			} else {
-				for j := 0; j < 2; j++ {
-					fmt.Printf("%s, ", formatDuration(task.Sessions[j].Duration))
-				}
-				fmt.Printf("... +%d more", len(task.Sessions)-2)
+func findTaskByID(taskList *TaskList, id int64) (int, error) {
+	for i := range taskList.Items {
+		if taskList.Items[i].ID == id {
+			return i + 1, nil
+		}
+	}
+	return 0, newError(errNotFound, "task %d no longer exists", id)
+}
+
+// taskInput is what the user typed to add a task, with any options
+// parsed out of the title.
+type taskInput struct {
+	Title    string
+	Priority string
+	Due      *time.Time
+	Tags     []string
+}
+
+func parseTaskInput(input string) (taskInput, error) {
+	level, words := extractFlag(strings.Fields(input), "--priority")
+	priority, err := parsePriority(level)
+	if err != nil {
+		return taskInput{}, err
+	}
+
+	parsed := taskInput{Priority: priority}
+	var title []string
+	for _, word := range words {
+		if value, ok := strings.CutPrefix(word, "due:"); ok {
+			due, err := parseDueDate(value, time.Now())
+			if err != nil {
+				return taskInput{}, err
 			}
-			fmt.Println()
+			parsed.Due = &due
+			continue
 		}
+		title = append(title, word)
 	}
+
+	title, parsed.Tags = splitTags(title)
+	parsed.Title = strings.Join(title, " ")
+	if parsed.Title == "" {
+		return taskInput{}, newError(errInvalidArgument, "task title cannot be empty")
+	}
+	return parsed, nil
 }
 
-func addTask(taskList *TaskList, title string) {
+func addTask(taskList *TaskList, input taskInput) *Task {
 	newTask := Task{
 		ID:            time.Now().UnixNano(),
-		Title:         title,
+		Title:         input.Title,
 		Status:        StatusPending,
+		Priority:      input.Priority,
+		Due:           input.Due,
+		Tags:          input.Tags,
 		Comment:       "",
 		Sessions:      []Session{},
 		TotalDuration: 0,
@@ -287,30 +607,66 @@ func addTask(taskList *TaskList, title string) {
 	}
 
 	taskList.Items = append(taskList.Items, newTask)
-	fmt.Printf("✨ Added: %s\n", title)
+	fmt.Fprintf(notices, "✨ Added: %s\n", taskLabel(&newTask))
+	return &taskList.Items[len(taskList.Items)-1]
+}
+
+// taskLabel is the title as displayed, prefixed with the priority and
+// followed by the tags.
+func taskLabel(task *Task) string {
+	label := task.Title
+	if task.Priority != "" {
+		label = fmt.Sprintf("(%s) %s", task.Priority, label)
+	}
+	for _, tag := range task.Tags {
+		label += " " + tagWord(tag)
+	}
+	return label
+}
+
+func setTaskNote(taskList *TaskList, index int, note string) error {
+	if index < 1 || index > len(taskList.Items) {
+		return newError(errNotFound, "invalid task number. Use 1-%d", len(taskList.Items))
+	}
+
+	task := &taskList.Items[index-1]
+	task.Comment = strings.TrimSpace(note)
+	if task.Comment == "" {
+		fmt.Fprintf(notices, "📝 Cleared note: %s\n", task.Title)
+	} else {
+		fmt.Fprintf(notices, "📝 Updated note: %s\n", task.Title)
+	}
+	return nil
 }
 
 func removeTask(taskList *TaskList, index int) error {
 	if index < 1 || index > len(taskList.Items) {
-		return fmt.Errorf("invalid task number. Use 1-%d", len(taskList.Items))
+		return newError(errNotFound, "invalid task number. Use 1-%d", len(taskList.Items))
 	}
 
 	removedTask := taskList.Items[index-1]
 	taskList.Items = append(taskList.Items[:index-1], taskList.Items[index:]...)
 
-	fmt.Printf("🗑️ Removed: %s\n", removedTask.Title)
+	// Subtasks move up to the removed task's parent.
+	for i := range taskList.Items {
+		if taskList.Items[i].ParentID == removedTask.ID {
+			taskList.Items[i].ParentID = removedTask.ParentID
+		}
+	}
+
+	fmt.Fprintf(notices, "🗑️ Removed: %s\n", removedTask.Title)
 	return nil
 }
 
 func toggleTaskTimer(taskList *TaskList, index int) error {
 	if index < 1 || index > len(taskList.Items) {
-		return fmt.Errorf("invalid task number. Use 1-%d", len(taskList.Items))
+		return newError(errNotFound, "invalid task number. Use 1-%d", len(taskList.Items))
 	}
 
 	task := &taskList.Items[index-1]
 
 	if task.Status == StatusDone {
-		return fmt.Errorf("cannot start timer for completed task")
+		return newError(errConflict, "cannot start timer for completed task")
 	}
 
 	now := time.Now()
@@ -324,11 +680,14 @@ func toggleTaskTimer(taskList *TaskList, index int) error {
 		}
 		task.Status = StatusActive
 		task.ActiveStartTime = &now
-		fmt.Printf("▶️ Started: %s\n", task.Title)
+		fmt.Fprintf(notices, "▶️ Started: %s\n", task.Title)
+		if len(task.BlockedBy) > 0 {
+			fmt.Fprintf(notices, "⚠️ %s is still blocked by %d open task(s)\n", task.Title, len(task.BlockedBy))
+		}
 
 	case StatusActive:
 		stopTaskTimer(task, now)
-		fmt.Printf("⏸️ Paused: %s [Session: %s] [Total: %s]\n", 
+		fmt.Fprintf(notices, "⏸️ Paused: %s [Session: %s] [Total: %s]\n", 
 			task.Title, 
 			formatDuration(task.Sessions[len(task.Sessions)-1].Duration),
 			task.GetFormattedDuration())
@@ -350,31 +709,53 @@ func stopTaskTimer(task *Task, endTime time.Time) {
 	}
 
 	task.Sessions = append(task.Sessions, session)
-	task.TotalDuration += duration.Nanoseconds()
+	recomputeTotal(task)
 	task.Status = StatusPaused
 	task.ActiveStartTime = nil
 }
 
-func markTaskComplete(taskList *TaskList, index int) error {
+func markTaskComplete(taskList *TaskList, index int, withSubtasks bool) error {
 	if index < 1 || index > len(taskList.Items) {
-		return fmt.Errorf("invalid task number. Use 1-%d", len(taskList.Items))
+		return newError(errNotFound, "invalid task number. Use 1-%d", len(taskList.Items))
 	}
 
 	task := &taskList.Items[index-1]
 	now := time.Now()
-
-	if task.Status == StatusActive {
-		stopTaskTimer(task, now)
-	}
-
-	task.Status = StatusDone
-	task.CompletedAt = &now
+	finishTask(task, now)
 
 	totalTime := ""
 	if task.TotalDuration > 0 {
 		totalTime = fmt.Sprintf(" [Total time: %s]", task.GetFormattedDuration())
 	}
 
-	fmt.Printf("✅ Completed: %s%s\n", task.Title, totalTime)
+	fmt.Fprintf(notices, "✅ Completed: %s%s\n", task.Title, totalTime)
+	closeSubtasks(taskList, task, now, withSubtasks)
 	return nil
-}
\ No newline at end of file
+}
+
+func reopenTask(taskList *TaskList, index int) error {
+	if index < 1 || index > len(taskList.Items) {
+		return newError(errNotFound, "invalid task number. Use 1-%d", len(taskList.Items))
+	}
+
+	task := &taskList.Items[index-1]
+	if task.Status != StatusDone {
+		return newError(errConflict, "task '%s' is not completed", task.Title)
+	}
+
+	entry := AuditEntry{Action: "reopened", At: time.Now()}
+	if task.CompletedAt != nil {
+		entry.Detail = fmt.Sprintf("was completed at %s", task.CompletedAt.Format(time.RFC3339))
+	}
+	task.History = append(task.History, entry)
+
+	if len(task.Sessions) > 0 {
+		task.Status = StatusPaused
+	} else {
+		task.Status = StatusPending
+	}
+	task.CompletedAt = nil
+
+	fmt.Fprintf(notices, "↩️ Reopened: %s\n", task.Title)
+	return nil
+}
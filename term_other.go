@@ -0,0 +1,23 @@
+//go:build !(linux || darwin || freebsd || netbsd || openbsd || dragonfly)
+
+package main
+
+import (
+	"errors"
+	"os"
+)
+
+var errNoTerminal = errors.New("terminal control not supported on this platform")
+
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+func makeRaw(fd int) (func(), error) {
+	return nil, errNoTerminal
+}
+
+func terminalSize(fd int) (int, int, error) {
+	return 0, 0, errNoTerminal
+}
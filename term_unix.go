@@ -0,0 +1,55 @@
+//go:build linux || darwin || freebsd || netbsd || openbsd || dragonfly
+
+package main
+
+import (
+	"os"
+	"syscall"
+	"unsafe"
+)
+
+func isTerminal(f *os.File) bool {
+	var termios syscall.Termios
+	return ioctl(int(f.Fd()), ioctlGetTermios, unsafe.Pointer(&termios)) == nil
+}
+
+func makeRaw(fd int) (func(), error) {
+	var original syscall.Termios
+	if err := ioctl(fd, ioctlGetTermios, unsafe.Pointer(&original)); err != nil {
+		return nil, err
+	}
+
+	raw := original
+	raw.Iflag &^= syscall.IGNBRK | syscall.BRKINT | syscall.PARMRK | syscall.ISTRIP |
+		syscall.INLCR | syscall.IGNCR | syscall.ICRNL | syscall.IXON
+	raw.Lflag &^= syscall.ECHO | syscall.ECHONL | syscall.ICANON | syscall.ISIG | syscall.IEXTEN
+	raw.Cflag &^= syscall.CSIZE | syscall.PARENB
+	raw.Cflag |= syscall.CS8
+	raw.Cc[syscall.VMIN] = 1
+	raw.Cc[syscall.VTIME] = 0
+	if err := ioctl(fd, ioctlSetTermios, unsafe.Pointer(&raw)); err != nil {
+		return nil, err
+	}
+
+	return func() {
+		ioctl(fd, ioctlSetTermios, unsafe.Pointer(&original))
+	}, nil
+}
+
+func terminalSize(fd int) (int, int, error) {
+	var size struct {
+		rows, cols, xpixel, ypixel uint16
+	}
+	if err := ioctl(fd, syscall.TIOCGWINSZ, unsafe.Pointer(&size)); err != nil {
+		return 0, 0, err
+	}
+	return int(size.cols), int(size.rows), nil
+}
+
+func ioctl(fd int, request uintptr, arg unsafe.Pointer) error {
+	_, _, errno := syscall.Syscall(syscall.SYS_IOCTL, uintptr(fd), request, uintptr(arg))
+	if errno != 0 {
+		return errno
+	}
+	return nil
+}
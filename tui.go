@@ -0,0 +1,756 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+type tuiPane int
+
+const (
+	paneTasks tuiPane = iota
+	paneLists
+)
+
+type tuiMode int
+
+const (
+	modeNormal tuiMode = iota
+	modeAddTask
+	modeCreateList
+	modeConfirmDelete
+	modeConfirmRemoveList
+	modeSetPriority
+	modeSetTags
+	modeFilter
+	modeAddSubtask
+)
+
+type tuiLine struct {
+	text string
+	row  int
+}
+
+type tui struct {
+	config     *Config
+	opts       DisplayOptions
+	files      []string
+	listIdx    int
+	listCursor int
+	taskFile   string
+	taskList   *TaskList
+	rows       []int
+	cursor     int
+	selectedID int64
+	offset     int
+	focus      tuiPane
+	mode       tuiMode
+	input      []rune
+	message    string
+	keys       *keyReader
+	restore    func()
+	changes    <-chan struct{}
+	stopWatch  func()
+}
+
+func canRunTUI() bool {
+	return isTerminal(os.Stdin) && isTerminal(os.Stdout)
+}
+
+func runTUI(config *Config, taskFiles []string, opts DisplayOptions) error {
+	t := &tui{config: config, opts: opts, files: taskFiles}
+	for i, file := range taskFiles {
+		if strings.TrimSuffix(file, ".json") == config.DefaultList {
+			t.listIdx = i
+		}
+	}
+
+	if err := t.enter(); err != nil {
+		return err
+	}
+	defer t.leave()
+
+	t.keys = newKeyReader(os.Stdin)
+	defer t.keys.stop()
+
+	askSessionEnd = func(task *Task, start, end, trimmed time.Time) time.Time {
+		t.leave()
+		defer t.reenter()
+		return promptSessionEnd(task, start, end, trimmed)
+	}
+	defer func() { askSessionEnd = promptSessionEnd }()
+
+	askCloseSubtasks = func(task *Task, open int) bool {
+		t.leave()
+		defer t.reenter()
+		return promptCloseSubtasks(task, open)
+	}
+	defer func() { askCloseSubtasks = promptCloseSubtasks }()
+
+	t.openList(t.listIdx)
+	defer func() {
+		if t.stopWatch != nil {
+			t.stopWatch()
+		}
+	}()
+
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	t.draw()
+	for {
+		select {
+		case chunk, ok := <-t.keys.wait():
+			t.keys.consumed()
+			if !ok {
+				return nil
+			}
+			noteActivity()
+			for _, k := range parseKeys(chunk) {
+				if !t.handleKey(k) {
+					return nil
+				}
+			}
+		case <-t.changes:
+			if t.taskList != nil && changedOnDisk(t.taskFile, t.taskList) {
+				if fresh, err := loadTasks(t.taskFile); err == nil {
+					*t.taskList = *fresh
+					t.buildRows()
+				}
+			}
+		case <-ticker.C:
+			t.refreshFiles()
+		}
+		t.draw()
+	}
+}
+
+func (t *tui) enter() error {
+	restore, err := makeRaw(int(os.Stdin.Fd()))
+	if err != nil {
+		return err
+	}
+	t.restore = restore
+	warnings = io.Discard
+	fmt.Print("\033[?1049h\033[?25l")
+	return nil
+}
+
+func (t *tui) leave() {
+	fmt.Print("\033[?25h\033[?1049l")
+	t.restore()
+	warnings = os.Stderr
+}
+
+// reenter restores the full screen after leave, exiting if the terminal
+// can no longer be put into raw mode.
+func (t *tui) reenter() {
+	if err := t.enter(); err != nil {
+		fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func (t *tui) openList(index int) {
+	if t.stopWatch != nil {
+		t.stopWatch()
+		t.stopWatch = nil
+	}
+
+	t.listIdx = index
+	t.listCursor = index
+	t.taskFile = filepath.Join(t.config.TaskDir, t.files[index])
+	t.cursor, t.offset, t.selectedID = 0, 0, 0
+
+	taskList, err := loadTasks(t.taskFile)
+	if err != nil {
+		t.taskList = nil
+		t.rows = nil
+		t.message = fmt.Sprintf("❌ Error loading tasks: %v", err)
+		return
+	}
+	t.taskList = taskList
+	t.buildRows()
+	t.changes, t.stopWatch = watchFile(t.taskFile)
+	if issues := validateTaskList(taskList); len(issues) > 0 {
+		t.message = fmt.Sprintf("⚠️ %d problem(s) in this list, run 'tgo fsck'", len(issues))
+	}
+}
+
+// closeList leaves the no-lists state behind after the last list is
+// removed: nothing is open and only the list pane takes keys.
+func (t *tui) closeList() {
+	if t.stopWatch != nil {
+		t.stopWatch()
+		t.stopWatch = nil
+	}
+	t.files = nil
+	t.listIdx, t.listCursor = 0, 0
+	t.taskFile, t.taskList, t.changes = "", nil, nil
+	t.rows = nil
+	t.cursor, t.offset, t.selectedID = 0, 0, 0
+	t.focus = paneLists
+}
+
+func (t *tui) refreshFiles() {
+	taskFiles, err := findTaskFiles(t.config.TaskDir)
+	if err != nil {
+		return
+	}
+
+	current := ""
+	if len(t.files) > 0 {
+		current = t.files[t.listIdx]
+	}
+	t.files = taskFiles
+	t.listIdx = 0
+	for i, file := range taskFiles {
+		if file == current {
+			t.listIdx = i
+		}
+	}
+	if t.listCursor >= len(t.files) {
+		t.listCursor = len(t.files) - 1
+	}
+	if t.files[t.listIdx] != current {
+		t.openList(t.listIdx)
+	}
+}
+
+func (t *tui) buildRows() {
+	t.rows = t.rows[:0]
+	t.rows = append(t.rows, nestTasks(t.taskList, t.opts.tasks(t.taskList, StatusActive))...)
+	t.rows = append(t.rows, nestTasks(t.taskList, t.opts.tasks(t.taskList, StatusPending, StatusPaused))...)
+	t.rows = append(t.rows, nestTasks(t.taskList, t.opts.tasks(t.taskList, StatusDone))...)
+
+	for i, index := range t.rows {
+		if t.taskList.Items[index].ID == t.selectedID {
+			t.cursor = i
+		}
+	}
+	t.moveCursor(0)
+}
+
+func (t *tui) moveCursor(delta int) {
+	t.cursor += delta
+	if t.cursor >= len(t.rows) {
+		t.cursor = len(t.rows) - 1
+	}
+	if t.cursor < 0 {
+		t.cursor = 0
+	}
+	if task := t.selected(); task != nil {
+		t.selectedID = task.ID
+	}
+}
+
+func (t *tui) selected() *Task {
+	if t.taskList == nil || t.cursor >= len(t.rows) {
+		return nil
+	}
+	return &t.taskList.Items[t.rows[t.cursor]]
+}
+
+// run executes an action, showing its last notice or error in the
+// message line instead of letting it scroll over the screen.
+func (t *tui) run(action func() error) {
+	var buf bytes.Buffer
+	notices = &buf
+	err := action()
+	notices = os.Stdout
+
+	if err != nil {
+		t.message = fmt.Sprintf("❌ %v", err)
+	} else {
+		lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+		t.message = lines[len(lines)-1]
+	}
+	if t.taskList != nil {
+		t.buildRows()
+	}
+}
+
+func (t *tui) handleKey(k key) bool {
+	if k.code == keyCtrlC {
+		return false
+	}
+	if t.mode != modeNormal {
+		t.handleInputKey(k)
+		return true
+	}
+
+	t.message = ""
+	if k.code == keyTab || (k.code == keyLeft && t.focus == paneTasks) || (k.code == keyRight && t.focus == paneLists) {
+		if t.focus == paneTasks || t.taskList == nil {
+			t.focus = paneLists
+		} else {
+			t.focus = paneTasks
+		}
+		return true
+	}
+	if k.code == keyRune && k.r == 'q' {
+		return false
+	}
+
+	if t.focus == paneLists {
+		t.handleListKey(k)
+	} else {
+		t.handleTaskKey(k)
+	}
+	return true
+}
+
+func (t *tui) handleListKey(k key) {
+	switch {
+	case k.code == keyUp || (k.code == keyRune && k.r == 'k'):
+		if t.listCursor > 0 {
+			t.openList(t.listCursor - 1)
+		}
+	case k.code == keyDown || (k.code == keyRune && k.r == 'j'):
+		if t.listCursor < len(t.files)-1 {
+			t.openList(t.listCursor + 1)
+		}
+	case k.code == keyEnter:
+		if t.taskList != nil {
+			t.focus = paneTasks
+		}
+	case k.code == keyRune && k.r == 'c':
+		t.mode = modeCreateList
+		t.input = nil
+	case k.code == keyDelete || (k.code == keyRune && k.r == 'x'):
+		if len(t.files) > 0 {
+			t.mode = modeConfirmRemoveList
+		}
+	}
+}
+
+func (t *tui) handleTaskKey(k key) {
+	switch {
+	case k.code == keyUp || (k.code == keyRune && k.r == 'k'):
+		t.moveCursor(-1)
+	case k.code == keyDown || (k.code == keyRune && k.r == 'j'):
+		t.moveCursor(1)
+	case k.code == keyHome || (k.code == keyRune && k.r == 'g'):
+		t.moveCursor(-len(t.rows))
+	case k.code == keyEnd || (k.code == keyRune && k.r == 'G'):
+		t.moveCursor(len(t.rows))
+	case k.code == keyRune && k.r == 'a':
+		if t.taskList != nil {
+			t.mode = modeAddTask
+			t.input = nil
+		}
+	case k.code == keyRune && k.r == 'N':
+		t.opts.HideNotes = !t.opts.HideNotes
+	case k.code == keyRune && k.r == '/' && t.taskList != nil:
+		t.mode = modeFilter
+		t.input = []rune(t.opts.Tag)
+	}
+
+	task := t.selected()
+	if task == nil {
+		return
+	}
+
+	switch {
+	case k.code == keyEnter || (k.code == keyRune && k.r == ' '):
+		t.run(func() error {
+			return updateTaskByID(t.taskFile, t.taskList, task.ID, toggleTaskTimer)
+		})
+	case k.code == keyRune && k.r == 'd':
+		taskNum, err := findTaskByID(t.taskList, task.ID)
+		if err != nil {
+			return
+		}
+		withSubtasks := confirmCloseSubtasks(t.taskList, taskNum)
+		t.run(func() error {
+			return updateTaskByID(t.taskFile, t.taskList, task.ID, completeTask(withSubtasks))
+		})
+	case k.code == keyRune && k.r == 'u':
+		t.run(func() error {
+			return updateTaskByID(t.taskFile, t.taskList, task.ID, reopenTask)
+		})
+	case k.code == keyDelete || (k.code == keyRune && k.r == 'x'):
+		t.mode = modeConfirmDelete
+	case k.code == keyRune && k.r == 'n':
+		t.editNote(task.ID, task.Comment)
+	case k.code == keyRune && k.r == 'p':
+		t.mode = modeSetPriority
+		t.input = []rune(task.Priority)
+	case k.code == keyRune && k.r == 'A':
+		t.mode = modeAddSubtask
+		t.input = nil
+	case k.code == keyRune && k.r == 't':
+		t.mode = modeSetTags
+		t.input = nil
+		for _, tag := range task.Tags {
+			t.input = append(t.input, []rune(tagWord(tag)+" ")...)
+		}
+	}
+}
+
+func (t *tui) handleInputKey(k key) {
+	switch t.mode {
+	case modeConfirmDelete, modeConfirmRemoveList:
+		confirmed := k.code == keyRune && (k.r == 'y' || k.r == 'Y')
+		mode := t.mode
+		t.mode = modeNormal
+		if !confirmed {
+			return
+		}
+		if mode == modeConfirmDelete {
+			if task := t.selected(); task != nil {
+				t.run(func() error {
+					return updateTaskByID(t.taskFile, t.taskList, task.ID, removeTask)
+				})
+			}
+			return
+		}
+		t.removeList()
+		return
+	}
+
+	switch k.code {
+	case keyEsc:
+		t.mode = modeNormal
+	case keyBackspace:
+		if len(t.input) > 0 {
+			t.input = t.input[:len(t.input)-1]
+		}
+	case keyRune:
+		t.input = append(t.input, k.r)
+	case keyEnter:
+		text := strings.TrimSpace(string(t.input))
+		mode := t.mode
+		t.mode = modeNormal
+		switch mode {
+		case modeSetPriority:
+			t.setPriority(text)
+			return
+		case modeSetTags:
+			t.setTags(text)
+			return
+		case modeFilter:
+			t.setFilter(text)
+			return
+		}
+		if text == "" {
+			return
+		}
+		if mode == modeAddTask {
+			t.run(func() error {
+				input, err := parseTaskInput(text)
+				if err != nil {
+					return err
+				}
+				return updateTasks(t.taskFile, t.taskList, func(taskList *TaskList) error {
+					t.selectedID = addTask(taskList, input).ID
+					return nil
+				})
+			})
+			return
+		}
+		if mode == modeAddSubtask {
+			t.addSubtask(text)
+			return
+		}
+		t.createList(text)
+	}
+}
+
+func (t *tui) setPriority(level string) {
+	task := t.selected()
+	if task == nil {
+		return
+	}
+	id := task.ID
+	t.run(func() error {
+		priority, err := parsePriority(level)
+		if err != nil {
+			return err
+		}
+		return updateTaskByID(t.taskFile, t.taskList, id, func(taskList *TaskList, taskNum int) error {
+			return setTaskPriority(taskList, taskNum, priority)
+		})
+	})
+}
+
+func (t *tui) addSubtask(text string) {
+	parent := t.selected()
+	if parent == nil {
+		return
+	}
+	parentID := parent.ID
+	t.run(func() error {
+		input, err := parseTaskInput(text)
+		if err != nil {
+			return err
+		}
+		return updateTasks(t.taskFile, t.taskList, func(taskList *TaskList) error {
+			task, err := addSubtask(taskList, parentID, input)
+			if err != nil {
+				return err
+			}
+			t.selectedID = task.ID
+			return nil
+		})
+	})
+}
+
+func (t *tui) setTags(text string) {
+	task := t.selected()
+	if task == nil {
+		return
+	}
+	id := task.ID
+	t.run(func() error {
+		tags, err := editTags(nil, strings.Fields(text))
+		if err != nil {
+			return err
+		}
+		return updateTaskByID(t.taskFile, t.taskList, id, func(taskList *TaskList, taskNum int) error {
+			return setTaskTags(taskList, taskNum, tags)
+		})
+	})
+}
+
+func (t *tui) setFilter(text string) {
+	t.opts.Tag = ""
+	t.message = ""
+	if text != "" {
+		tag, ok := normalizeTag(text)
+		if !ok {
+			t.message = fmt.Sprintf("❌ Invalid tag '%s'", text)
+			return
+		}
+		t.opts.Tag = tag
+		t.message = "🏷️ Showing #" + tag
+	}
+	t.buildRows()
+}
+
+func (t *tui) editNote(id int64, comment string) {
+	t.leave()
+	note, err := editText(comment)
+	t.reenter()
+	if err != nil {
+		t.message = fmt.Sprintf("❌ %v", err)
+		return
+	}
+
+	t.run(func() error {
+		return updateTaskByID(t.taskFile, t.taskList, id, func(taskList *TaskList, taskNum int) error {
+			return setTaskNote(taskList, taskNum, note)
+		})
+	})
+}
+
+func (t *tui) createList(listName string) {
+	if err := createNewList(t.config.TaskDir, listName); err != nil {
+		t.message = fmt.Sprintf("❌ %v", err)
+		return
+	}
+
+	t.refreshFiles()
+	created := sanitizeListName(listName) + ".json"
+	for i, file := range t.files {
+		if file == created {
+			t.openList(i)
+		}
+	}
+	t.message = fmt.Sprintf("✅ Created list: %s", listName)
+}
+
+func (t *tui) removeList() {
+	selectedFile := t.files[t.listCursor]
+	if err := removeListFile(filepath.Join(t.config.TaskDir, selectedFile)); err != nil {
+		t.message = fmt.Sprintf("❌ Failed to remove: %v", err)
+		return
+	}
+	t.message = fmt.Sprintf("✅ Removed: %s", selectedFile)
+
+	taskFiles, err := findTaskFiles(t.config.TaskDir)
+	if err != nil {
+		t.closeList()
+		return
+	}
+	t.files = taskFiles
+	index := t.listCursor
+	if index >= len(t.files) {
+		index = len(t.files) - 1
+	}
+	t.openList(index)
+}
+
+func (t *tui) draw() {
+	width, height, err := terminalSize(int(os.Stdout.Fd()))
+	if err != nil || width < 20 || height < 5 {
+		width, height = 80, 24
+	}
+
+	listWidth := 24
+	if width/3 < listWidth {
+		listWidth = width / 3
+	}
+	taskWidth := width - listWidth - 1
+	bodyHeight := height - 3
+
+	listLines := t.renderLists(listWidth)
+	taskLines, cursorLine := t.renderTasks()
+
+	if cursorLine >= 0 {
+		if cursorLine < t.offset {
+			t.offset = cursorLine
+		}
+		if cursorLine >= t.offset+bodyHeight {
+			t.offset = cursorLine - bodyHeight + 1
+		}
+	}
+	if t.offset > len(taskLines)-bodyHeight {
+		t.offset = max(len(taskLines)-bodyHeight, 0)
+	}
+
+	title := "tgo"
+	if t.taskList != nil {
+		title = fmt.Sprintf("tgo ─ %s", listDisplayName(t.taskList, t.files[t.listIdx]))
+	}
+
+	lines := []string{"\033[7m" + padRight(" "+title, width) + "\033[0m"}
+	for y := 0; y < bodyHeight; y++ {
+		left := padRight("", listWidth)
+		if y < len(listLines) {
+			left = listLines[y]
+		}
+
+		right := ""
+		if y+t.offset < len(taskLines) {
+			line := taskLines[y+t.offset]
+			right = padRight(" "+line.text, taskWidth)
+			if t.focus == paneTasks && line.row >= 0 && line.row == t.cursor {
+				right = "\033[7m" + right + "\033[0m"
+			}
+		}
+		lines = append(lines, left+"│"+right)
+	}
+	lines = append(lines, padRight(t.statusLine(), width))
+	lines = append(lines, "\033[7m"+padRight(" "+t.helpLine(), width)+"\033[0m")
+
+	fmt.Print("\033[H" + strings.Join(lines, "\033[K\r\n") + "\033[K\033[J")
+}
+
+func (t *tui) renderLists(width int) []string {
+	lines := []string{padRight(" Lists", width), padRight("", width)}
+	for i, file := range t.files {
+		marker := "  "
+		if i == t.listIdx {
+			marker = "› "
+		}
+		line := padRight(" "+marker+strings.TrimSuffix(file, ".json"), width)
+		if i == t.listCursor && t.focus == paneLists {
+			line = "\033[7m" + line + "\033[0m"
+		}
+		lines = append(lines, line)
+	}
+	if len(t.files) == 0 {
+		lines = append(lines, padRight(" No lists yet.", width), padRight(" Press 'c' to create one.", width))
+	}
+	return lines
+}
+
+func (t *tui) renderTasks() ([]tuiLine, int) {
+	if t.taskList == nil {
+		return nil, -1
+	}
+
+	activeCount, pendingCount, doneCount := countTasks(t.taskList)
+	header := fmt.Sprintf("Active: %d │ Pending: %d │ Done: %d", activeCount, pendingCount, doneCount)
+	if t.opts.Tag != "" {
+		header += " │ 🏷️ #" + t.opts.Tag
+	}
+	lines := []tuiLine{
+		{text: header, row: -1},
+		{row: -1},
+	}
+	switch {
+	case len(t.rows) == 0 && t.opts.Tag != "":
+		lines = append(lines, tuiLine{text: "No tasks tagged #" + t.opts.Tag + ". Press '/' to change the filter.", row: -1})
+	case len(t.rows) == 0:
+		lines = append(lines, tuiLine{text: "No tasks yet. Press 'a' to add one.", row: -1})
+	}
+
+	idLength := shortIDLength(t.taskList)
+	cursorLine := -1
+	for row, index := range t.rows {
+		task := &t.taskList.Items[index]
+		statusIcon, timeInfo := taskStatusInfo(task)
+		if row == t.cursor {
+			cursorLine = len(lines)
+		}
+		indent := subtaskIndent(t.taskList, task)
+		lines = append(lines, tuiLine{
+			text: fmt.Sprintf("%s%s %s %s%s%s%s%s", indent, task.Hash()[:idLength], statusIcon, taskLabel(task), timeInfo,
+				taskDueInfo(task, time.Now()), taskBlockedInfo(t.taskList, task), subtaskInfo(t.taskList, task)),
+			row: row,
+		})
+
+		if task.Comment != "" && !t.opts.HideNotes {
+			pad := strings.Repeat(" ", len([]rune(indent))+5)
+			for _, note := range strings.Split(task.Comment, "\n") {
+				lines = append(lines, tuiLine{text: pad + note, row: row})
+			}
+		}
+	}
+	return lines, cursorLine
+}
+
+func (t *tui) statusLine() string {
+	switch t.mode {
+	case modeAddTask:
+		return " New task: " + string(t.input) + "█"
+	case modeAddSubtask:
+		if task := t.selected(); task != nil {
+			return fmt.Sprintf(" New subtask of '%s': %s█", task.Title, string(t.input))
+		}
+	case modeCreateList:
+		return " New list: " + string(t.input) + "█"
+	case modeSetPriority:
+		return " Priority (A-Z, P0-P3, empty clears): " + string(t.input) + "█"
+	case modeSetTags:
+		return " Tags: " + string(t.input) + "█"
+	case modeFilter:
+		return " Filter by tag (empty clears): " + string(t.input) + "█"
+	case modeConfirmDelete:
+		if task := t.selected(); task != nil {
+			return fmt.Sprintf(" Delete '%s'? (y/N)", task.Title)
+		}
+	case modeConfirmRemoveList:
+		if len(t.files) > 0 {
+			return fmt.Sprintf(" Remove list '%s'? (y/N)", t.files[t.listCursor])
+		}
+	}
+	return " " + t.message
+}
+
+func (t *tui) helpLine() string {
+	switch {
+	case t.mode == modeAddTask || t.mode == modeCreateList || t.mode == modeSetPriority ||
+		t.mode == modeSetTags || t.mode == modeFilter || t.mode == modeAddSubtask:
+		return "⏎ confirm  esc cancel"
+	case t.mode != modeNormal:
+		return "y confirm  any other key cancels"
+	case t.focus == paneLists:
+		return "↑↓ select  ⏎ open  c new list  x remove  ⇥ tasks  q quit"
+	}
+	return "↑↓ move  space start/pause  d done  u reopen  x delete  a add  A subtask  n note  p prio  t tags  / filter  N notes  ⇥ lists  q quit"
+}
+
+func listDisplayName(taskList *TaskList, fileName string) string {
+	if taskList.Title != "" {
+		return taskList.Title
+	}
+	return strings.TrimSuffix(fileName, ".json")
+}
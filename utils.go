@@ -2,9 +2,15 @@ package main
 
 import (
 	"fmt"
+	"io"
+	"os"
+	"strings"
 	"time"
 )
 
+var notices io.Writer = os.Stdout
+
+var warnings io.Writer = os.Stderr
 
 func formatDuration(nanoseconds int64) string {
 	if nanoseconds == 0 {
@@ -22,4 +28,110 @@ func formatDuration(nanoseconds int64) string {
 		return fmt.Sprintf("%dm %ds", minutes, seconds)
 	}
 	return fmt.Sprintf("%ds", seconds)
-}
\ No newline at end of file
+}
+func runeWidth(r rune) int {
+	switch {
+	case r == 0x200d || (r >= 0xfe00 && r <= 0xfe0f) || (r >= 0x300 && r <= 0x36f):
+		return 0
+	case (r >= 0x1100 && r <= 0x115f) ||
+		(r >= 0x231a && r <= 0x231b) ||
+		(r >= 0x23e9 && r <= 0x23fa) ||
+		(r >= 0x25fd && r <= 0x25fe) ||
+		(r >= 0x2614 && r <= 0x2615) ||
+		(r >= 0x2648 && r <= 0x2653) ||
+		r == 0x267f || r == 0x2693 || r == 0x26a1 || (r >= 0x26aa && r <= 0x26ab) ||
+		(r >= 0x26bd && r <= 0x26be) || (r >= 0x26c4 && r <= 0x26c5) ||
+		r == 0x26ce || r == 0x26d4 || r == 0x26ea || (r >= 0x26f2 && r <= 0x26f5) ||
+		r == 0x26fa || r == 0x26fd || r == 0x2705 || (r >= 0x270a && r <= 0x270b) ||
+		r == 0x2728 || r == 0x274c || r == 0x274e || (r >= 0x2753 && r <= 0x2755) ||
+		r == 0x2757 || (r >= 0x2795 && r <= 0x2797) || r == 0x27b0 || r == 0x27bf ||
+		(r >= 0x2e80 && r <= 0xa4cf) ||
+		(r >= 0xac00 && r <= 0xd7a3) ||
+		(r >= 0xf900 && r <= 0xfaff) ||
+		(r >= 0xfe30 && r <= 0xfe4f) ||
+		(r >= 0xff00 && r <= 0xff60) ||
+		(r >= 0xffe0 && r <= 0xffe6) ||
+		(r >= 0x1f300 && r <= 0x1f64f) ||
+		(r >= 0x1f680 && r <= 0x1f6ff) ||
+		(r >= 0x1f7e0 && r <= 0x1f7eb) ||
+		(r >= 0x1f900 && r <= 0x1f9ff) ||
+		(r >= 0x20000 && r <= 0x3fffd):
+		return 2
+	}
+	return 1
+}
+
+func padRight(s string, width int) string {
+	var b strings.Builder
+	used := 0
+	for _, r := range s {
+		w := runeWidth(r)
+		if used+w > width {
+			break
+		}
+		b.WriteRune(r)
+		used += w
+	}
+	return b.String() + strings.Repeat(" ", width-used)
+}
+
+func parseTimeSpec(spec string, now time.Time) (time.Time, error) {
+	spec = strings.ToLower(strings.TrimSpace(spec))
+	if spec == "now" {
+		return now, nil
+	}
+	if rest, ok := strings.CutSuffix(spec, " ago"); ok {
+		if d, err := time.ParseDuration(strings.ReplaceAll(rest, " ", "")); err == nil {
+			return now.Add(-d), nil
+		}
+	}
+	if t, err := time.Parse(time.RFC3339, strings.ToUpper(spec)); err == nil {
+		return t, nil
+	}
+
+	now = now.Local()
+	day := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+	if len(spec) > 10 && spec[10] == 't' {
+		spec = spec[:10] + " " + spec[11:]
+	}
+	fields := strings.Fields(spec)
+	if len(fields) == 0 || len(fields) > 2 {
+		return time.Time{}, newError(errInvalidArgument, "invalid time '%s'", spec)
+	}
+
+	clock := fields[len(fields)-1]
+	if len(fields) == 2 || !strings.Contains(clock, ":") {
+		date, ok := parseDateWord(fields[0], day)
+		if !ok {
+			return time.Time{}, newError(errInvalidArgument, "invalid date '%s', use today, yesterday or YYYY-MM-DD", fields[0])
+		}
+		day = date
+		if len(fields) == 1 {
+			return day, nil
+		}
+	}
+
+	for _, layout := range []string{"15:04", "15:04:05"} {
+		if t, err := time.Parse(layout, clock); err == nil {
+			return day.Add(time.Duration(t.Hour())*time.Hour +
+				time.Duration(t.Minute())*time.Minute +
+				time.Duration(t.Second())*time.Second), nil
+		}
+	}
+	return time.Time{}, newError(errInvalidArgument, "invalid time of day '%s', use HH:MM", clock)
+}
+
+func parseDateWord(word string, today time.Time) (time.Time, bool) {
+	switch word {
+	case "today":
+		return today, true
+	case "yesterday":
+		return today.AddDate(0, 0, -1), true
+	case "tomorrow":
+		return today.AddDate(0, 0, 1), true
+	}
+	if t, err := time.ParseInLocation("2006-01-02", word, time.Local); err == nil {
+		return t, true
+	}
+	return time.Time{}, false
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseTimeSpec(t *testing.T) {
+	now := time.Date(2026, time.October, 14, 15, 30, 0, 0, time.Local)
+	at := func(day, hour, minute, second int) time.Time {
+		return time.Date(2026, time.October, day, hour, minute, second, 0, time.Local)
+	}
+
+	tests := []struct {
+		spec string
+		want time.Time
+	}{
+		{"now", now},
+		{" NOW ", now},
+		{"90m ago", now.Add(-90 * time.Minute)},
+		{"1h 30m ago", now.Add(-90 * time.Minute)},
+		{"14:00", at(14, 14, 0, 0)},
+		{"09:15:30", at(14, 9, 15, 30)},
+		{"today", at(14, 0, 0, 0)},
+		{"yesterday", at(13, 0, 0, 0)},
+		{"yesterday 14:00", at(13, 14, 0, 0)},
+		{"tomorrow 8:05", at(15, 8, 5, 0)},
+		{"2026-10-01", at(1, 0, 0, 0)},
+		{"2026-10-01 18:45", at(1, 18, 45, 0)},
+		{"2026-10-01T18:45", at(1, 18, 45, 0)},
+		{"2026-10-01T18:45:00Z", time.Date(2026, time.October, 1, 18, 45, 0, 0, time.UTC)},
+	}
+	for _, test := range tests {
+		got, err := parseTimeSpec(test.spec, now)
+		if err != nil {
+			t.Errorf("parseTimeSpec(%q) error: %v", test.spec, err)
+			continue
+		}
+		if !got.Equal(test.want) {
+			t.Errorf("parseTimeSpec(%q) = %v, want %v", test.spec, got, test.want)
+		}
+	}
+
+	for _, spec := range []string{"", "later", "ago", "soon ago", "25:00", "yesterday noon", "friday 10:00", "2026-10-01 18:45 extra"} {
+		if got, err := parseTimeSpec(spec, now); err == nil {
+			t.Errorf("parseTimeSpec(%q) = %v, want an error", spec, got)
+		}
+	}
+}
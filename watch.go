@@ -0,0 +1,47 @@
+package main
+
+import (
+	"os"
+	"time"
+)
+
+const watchPollInterval = 500 * time.Millisecond
+
+func pollFile(filePath string) (<-chan struct{}, func()) {
+	changes := make(chan struct{}, 1)
+	done := make(chan struct{})
+
+	go func() {
+		ticker := time.NewTicker(watchPollInterval)
+		defer ticker.Stop()
+
+		var lastMod time.Time
+		var lastSize int64
+		if info, err := os.Stat(filePath); err == nil {
+			lastMod, lastSize = info.ModTime(), info.Size()
+		}
+
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				info, err := os.Stat(filePath)
+				if err != nil || (info.ModTime().Equal(lastMod) && info.Size() == lastSize) {
+					continue
+				}
+				lastMod, lastSize = info.ModTime(), info.Size()
+				notifyChange(changes)
+			}
+		}
+	}()
+
+	return changes, func() { close(done) }
+}
+
+func notifyChange(changes chan struct{}) {
+	select {
+	case changes <- struct{}{}:
+	default:
+	}
+}
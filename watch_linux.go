@@ -0,0 +1,56 @@
+//go:build linux
+
+package main
+
+import (
+	"bytes"
+	"path/filepath"
+	"syscall"
+	"unsafe"
+)
+
+func watchFile(filePath string) (<-chan struct{}, func()) {
+	fd, err := syscall.InotifyInit1(syscall.IN_CLOEXEC)
+	if err != nil {
+		return pollFile(filePath)
+	}
+
+	// Saves replace the file via rename, so watch the directory rather
+	// than the file's inode.
+	mask := uint32(syscall.IN_CLOSE_WRITE | syscall.IN_MOVED_TO | syscall.IN_CREATE)
+	wd, err := syscall.InotifyAddWatch(fd, filepath.Dir(filePath), mask)
+	if err != nil {
+		syscall.Close(fd)
+		return pollFile(filePath)
+	}
+
+	changes := make(chan struct{}, 1)
+	name := []byte(filepath.Base(filePath))
+
+	go func() {
+		defer syscall.Close(fd)
+		buf := make([]byte, 64*(syscall.SizeofInotifyEvent+syscall.NAME_MAX+1))
+		for {
+			n, err := syscall.Read(fd, buf)
+			if err != nil || n <= 0 {
+				return
+			}
+
+			for offset := 0; offset+syscall.SizeofInotifyEvent <= n; {
+				event := (*syscall.InotifyEvent)(unsafe.Pointer(&buf[offset]))
+				if event.Mask&syscall.IN_IGNORED != 0 {
+					return
+				}
+
+				start := offset + syscall.SizeofInotifyEvent
+				eventName := bytes.TrimRight(buf[start:start+int(event.Len)], "\x00")
+				if bytes.Equal(eventName, name) {
+					notifyChange(changes)
+				}
+				offset = start + int(event.Len)
+			}
+		}
+	}()
+
+	return changes, func() { syscall.InotifyRmWatch(fd, uint32(wd)) }
+}
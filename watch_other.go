@@ -0,0 +1,7 @@
+//go:build !linux
+
+package main
+
+func watchFile(filePath string) (<-chan struct{}, func()) {
+	return pollFile(filePath)
+}